@@ -0,0 +1,122 @@
+package ff
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Backend is a set of Fr and G1 operations, so that code like fft.FFTSettings can be run against
+// a backend chosen at runtime, and different backends can be compared in the same process.
+//
+// All backends work on the Fr and G1Point types of the backend selected with build tags,
+// the native backend (see BackendName) is always registered, and is the default.
+type Backend interface {
+	// Name is the name the backend is registered with.
+	Name() string
+
+	AddModFr(dst *Fr, a, b *Fr)
+	SubModFr(dst *Fr, a, b *Fr)
+	MulModFr(dst *Fr, a, b *Fr)
+	DivModFr(dst *Fr, a, b *Fr)
+	InvModFr(dst *Fr, v *Fr)
+	NegModFr(dst *Fr, v *Fr)
+	EqualFr(a *Fr, b *Fr) bool
+
+	// FrFrom32 sets dst to the little-endian 32 byte value v, reduced modulo r.
+	FrFrom32(dst *Fr, v [32]byte)
+	// FrTo32 encodes the value as 32 bytes, little-endian.
+	FrTo32(src *Fr) [32]byte
+
+	AddG1(dst *G1Point, a *G1Point, b *G1Point)
+	SubG1(dst *G1Point, a *G1Point, b *G1Point)
+	MulG1(dst *G1Point, a *G1Point, b *Fr)
+	EqualG1(a *G1Point, b *G1Point) bool
+	// LinCombG1 computes the multi-scalar multiplication of the numbers with the factors.
+	LinCombG1(numbers []G1Point, factors []Fr) *G1Point
+
+	// PairingsVerify checks e(a1^(-1), a2) * e(b1,  b2) = 1_T
+	PairingsVerify(a1 *G1Point, a2 *G2Point, b1 *G1Point, b2 *G2Point) bool
+}
+
+var (
+	backendsLock sync.RWMutex
+	backends     = make(map[string]Backend)
+)
+
+func init() {
+	if err := RegisterBackend(nativeBackend{}); err != nil {
+		panic(err)
+	}
+	if err := RegisterBackend(referenceBackend{}); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterBackend makes the backend available through GetBackend. Names must be unique.
+func RegisterBackend(b Backend) error {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+	if _, ok := backends[b.Name()]; ok {
+		return fmt.Errorf("backend %q is already registered", b.Name())
+	}
+	backends[b.Name()] = b
+	return nil
+}
+
+// GetBackend returns the registered backend with the given name.
+func GetBackend(name string) (Backend, error) {
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+	b, ok := backends[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown backend %q", name)
+	}
+	return b, nil
+}
+
+// BackendNames returns the names of all registered backends, sorted.
+func BackendNames() []string {
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultBackend returns the native backend, selected with build tags.
+func DefaultBackend() Backend {
+	return nativeBackend{}
+}
+
+// nativeBackend forwards to the package-level functions of the build-tag selected backend.
+type nativeBackend struct{}
+
+func (nativeBackend) Name() string { return BackendName }
+
+func (nativeBackend) AddModFr(dst *Fr, a, b *Fr) { AddModFr(dst, a, b) }
+func (nativeBackend) SubModFr(dst *Fr, a, b *Fr) { SubModFr(dst, a, b) }
+func (nativeBackend) MulModFr(dst *Fr, a, b *Fr) { MulModFr(dst, a, b) }
+func (nativeBackend) DivModFr(dst *Fr, a, b *Fr) { DivModFr(dst, a, b) }
+func (nativeBackend) InvModFr(dst *Fr, v *Fr)    { InvModFr(dst, v) }
+func (nativeBackend) NegModFr(dst *Fr, v *Fr)    { NegModFr(dst, v) }
+func (nativeBackend) EqualFr(a *Fr, b *Fr) bool  { return EqualFr(a, b) }
+
+func (nativeBackend) FrFrom32(dst *Fr, v [32]byte) { FrFrom32(dst, v) }
+func (nativeBackend) FrTo32(src *Fr) [32]byte      { return FrTo32(src) }
+
+func (nativeBackend) AddG1(dst *G1Point, a *G1Point, b *G1Point) { AddG1(dst, a, b) }
+func (nativeBackend) SubG1(dst *G1Point, a *G1Point, b *G1Point) { SubG1(dst, a, b) }
+func (nativeBackend) MulG1(dst *G1Point, a *G1Point, b *Fr)      { MulG1(dst, a, b) }
+func (nativeBackend) EqualG1(a *G1Point, b *G1Point) bool        { return EqualG1(a, b) }
+
+func (nativeBackend) LinCombG1(numbers []G1Point, factors []Fr) *G1Point {
+	return LinCombG1(numbers, factors)
+}
+
+func (nativeBackend) PairingsVerify(a1 *G1Point, a2 *G2Point, b1 *G1Point, b2 *G2Point) bool {
+	return PairingsVerify(a1, a2, b1, b2)
+}
@@ -0,0 +1,182 @@
+package ff
+
+import "math/big"
+
+// ReferenceBackendName is the name of the always available reference backend.
+const ReferenceBackendName = "reference"
+
+func refModulus() *big.Int {
+	return &currentModulus
+}
+
+func refToBig(v *Fr) *big.Int {
+	b := FrTo32(v)
+	// reverse endianness, big.Int takes big-endian bytes
+	for i := 0; i < 16; i++ {
+		b[i], b[31-i] = b[31-i], b[i]
+	}
+	return new(big.Int).SetBytes(b[:])
+}
+
+func refFromBig(dst *Fr, v *big.Int) {
+	var b [32]byte
+	new(big.Int).Mod(v, refModulus()).FillBytes(b[:])
+	// reverse endianness, big.Int outputs big-endian bytes
+	for i := 0; i < 16; i++ {
+		b[i], b[31-i] = b[31-i], b[i]
+	}
+	FrFrom32(dst, b)
+}
+
+// refG1ToAffine decodes the point into math/big coordinates, with the native uncompressed encoding.
+func refG1ToAffine(p *G1Point) affinePoint[*big.Int] {
+	raw := g1ToUncompressed(p)
+	if raw[0]&infinityFlag != 0 {
+		return affinePoint[*big.Int]{inf: true}
+	}
+	return affinePoint[*big.Int]{x: new(big.Int).SetBytes(raw[:48]), y: new(big.Int).SetBytes(raw[48:])}
+}
+
+func refG1FromAffine(dst *G1Point, p affinePoint[*big.Int]) {
+	if p.inf {
+		ClearG1(dst)
+		return
+	}
+	var raw [G1UncompressedSize]byte
+	p.x.FillBytes(raw[:48])
+	p.y.FillBytes(raw[48:])
+	if err := g1FromUncompressedUnchecked(dst, &raw); err != nil {
+		panic(err)
+	}
+}
+
+// refG1 reports whether the G1 operations run on math/big, the affine formulas are only set up for BLS12-381.
+func refG1() bool {
+	return currentCurve == BLS12_381
+}
+
+// referenceBackend implements the Fr arithmetic with math/big, independently of the native backend,
+// only using it to encode and decode values. On BLS12-381 the G1 operations (AddG1, SubG1, MulG1, EqualG1
+// and LinCombG1) are computed with affine math/big formulas as well, the native backend only decodes
+// and encodes the points. On other curves AddG1, SubG1 and EqualG1 are forwarded to the native backend,
+// and MulG1 and LinCombG1 are plain double-and-add on top of the native AddG1.
+// PairingsVerify is always forwarded to the native backend, it is not independent.
+// It is slow, and meant for differential testing only.
+type referenceBackend struct{}
+
+func (referenceBackend) Name() string { return ReferenceBackendName }
+
+func (referenceBackend) AddModFr(dst *Fr, a, b *Fr) {
+	refFromBig(dst, new(big.Int).Add(refToBig(a), refToBig(b)))
+}
+
+func (referenceBackend) SubModFr(dst *Fr, a, b *Fr) {
+	refFromBig(dst, new(big.Int).Sub(refToBig(a), refToBig(b)))
+}
+
+func (referenceBackend) MulModFr(dst *Fr, a, b *Fr) {
+	refFromBig(dst, new(big.Int).Mul(refToBig(a), refToBig(b)))
+}
+
+func (r referenceBackend) DivModFr(dst *Fr, a, b *Fr) {
+	var tmp Fr
+	r.InvModFr(&tmp, b)
+	r.MulModFr(dst, a, &tmp)
+}
+
+// InvModFr maps zero to zero, like the native backends do.
+func (referenceBackend) InvModFr(dst *Fr, v *Fr) {
+	out := new(big.Int).ModInverse(refToBig(v), refModulus())
+	if out == nil {
+		out = new(big.Int)
+	}
+	refFromBig(dst, out)
+}
+
+func (referenceBackend) NegModFr(dst *Fr, v *Fr) {
+	refFromBig(dst, new(big.Int).Neg(refToBig(v)))
+}
+
+func (referenceBackend) EqualFr(a *Fr, b *Fr) bool {
+	return refToBig(a).Cmp(refToBig(b)) == 0
+}
+
+func (referenceBackend) FrFrom32(dst *Fr, v [32]byte) {
+	// reverse endianness, big.Int takes big-endian bytes
+	for i := 0; i < 16; i++ {
+		v[i], v[31-i] = v[31-i], v[i]
+	}
+	refFromBig(dst, new(big.Int).SetBytes(v[:]))
+}
+
+func (referenceBackend) FrTo32(src *Fr) [32]byte {
+	return FrTo32(src)
+}
+
+func (referenceBackend) AddG1(dst *G1Point, a *G1Point, b *G1Point) {
+	if !refG1() {
+		AddG1(dst, a, b)
+		return
+	}
+	refG1FromAffine(dst, pointAdd[*big.Int](fpField{}, refG1ToAffine(a), refG1ToAffine(b)))
+}
+
+func (referenceBackend) SubG1(dst *G1Point, a *G1Point, b *G1Point) {
+	if !refG1() {
+		SubG1(dst, a, b)
+		return
+	}
+	q := refG1ToAffine(b)
+	if !q.inf {
+		q.y = fpNeg(q.y)
+	}
+	refG1FromAffine(dst, pointAdd[*big.Int](fpField{}, refG1ToAffine(a), q))
+}
+
+func (referenceBackend) MulG1(dst *G1Point, a *G1Point, b *Fr) {
+	e := refToBig(b)
+	if refG1() {
+		refG1FromAffine(dst, pointMul[*big.Int](fpField{}, refG1ToAffine(a), e))
+		return
+	}
+	var out, tmp G1Point
+	ClearG1(&out)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		CopyG1(&tmp, &out)
+		AddG1(&out, &tmp, &tmp)
+		if e.Bit(i) == 1 {
+			CopyG1(&tmp, &out)
+			AddG1(&out, &tmp, a)
+		}
+	}
+	CopyG1(dst, &out)
+}
+
+func (referenceBackend) EqualG1(a *G1Point, b *G1Point) bool {
+	if !refG1() {
+		return EqualG1(a, b)
+	}
+	p, q := refG1ToAffine(a), refG1ToAffine(b)
+	if p.inf || q.inf {
+		return p.inf == q.inf
+	}
+	return p.x.Cmp(q.x) == 0 && p.y.Cmp(q.y) == 0
+}
+
+func (r referenceBackend) LinCombG1(numbers []G1Point, factors []Fr) *G1Point {
+	if len(numbers) != len(factors) {
+		panic("got LinCombG1 numbers/factors length mismatch")
+	}
+	var out, tmp, prod G1Point
+	ClearG1(&out)
+	for i := range numbers {
+		r.MulG1(&prod, &numbers[i], &factors[i])
+		CopyG1(&tmp, &out)
+		r.AddG1(&out, &tmp, &prod)
+	}
+	return &out
+}
+
+func (referenceBackend) PairingsVerify(a1 *G1Point, a2 *G2Point, b1 *G1Point, b2 *G2Point) bool {
+	return PairingsVerify(a1, a2, b1, b2)
+}
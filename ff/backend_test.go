@@ -0,0 +1,117 @@
+package ff
+
+import "testing"
+
+func TestBackendRegistry(t *testing.T) {
+	names := BackendNames()
+	for _, name := range []string{BackendName, ReferenceBackendName} {
+		b, err := GetBackend(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.Name() != name {
+			t.Errorf("got backend %q, expected %q", b.Name(), name)
+		}
+		found := false
+		for _, n := range names {
+			found = found || n == name
+		}
+		if !found {
+			t.Errorf("backend %q is not listed in %v", name, names)
+		}
+	}
+	if _, err := GetBackend("unknown"); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+	if err := RegisterBackend(DefaultBackend()); err == nil {
+		t.Error("expected error for duplicate backend registration")
+	}
+}
+
+func TestBackendsAgree(t *testing.T) {
+	native := DefaultBackend()
+	for _, name := range BackendNames() {
+		t.Run(name, func(t *testing.T) {
+			b, err := GetBackend(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ops := []struct {
+				name string
+				fn   func(bk Backend, dst *Fr, x, y *Fr)
+			}{
+				{"add", func(bk Backend, dst *Fr, x, y *Fr) { bk.AddModFr(dst, x, y) }},
+				{"sub", func(bk Backend, dst *Fr, x, y *Fr) { bk.SubModFr(dst, x, y) }},
+				{"mul", func(bk Backend, dst *Fr, x, y *Fr) { bk.MulModFr(dst, x, y) }},
+				{"div", func(bk Backend, dst *Fr, x, y *Fr) { bk.DivModFr(dst, x, y) }},
+				{"inv", func(bk Backend, dst *Fr, x, y *Fr) { bk.InvModFr(dst, x) }},
+				{"neg", func(bk Backend, dst *Fr, x, y *Fr) { bk.NegModFr(dst, x) }},
+			}
+			inputs := []Fr{ZERO, ONE, MODULUS_MINUS1, *RandomFr(), *RandomFr()}
+			for _, op := range ops {
+				for i := range inputs {
+					for j := range inputs {
+						var got, expected Fr
+						op.fn(b, &got, &inputs[i], &inputs[j])
+						op.fn(native, &expected, &inputs[i], &inputs[j])
+						if !EqualFr(&got, &expected) {
+							t.Errorf("%s(%s, %s): got %s, expected %s", op.name,
+								FrStr(&inputs[i]), FrStr(&inputs[j]), FrStr(&got), FrStr(&expected))
+						}
+					}
+				}
+			}
+
+			points := make([]G1Point, 4, 4)
+			factors := make([]Fr, 4, 4)
+			for i := range points {
+				MulG1(&points[i], &GenG1, RandomFr())
+				CopyFr(&factors[i], RandomFr())
+			}
+			if got, expected := b.LinCombG1(points, factors), native.LinCombG1(points, factors); !b.EqualG1(got, expected) {
+				t.Errorf("LinCombG1: got %s, expected %s", StrG1(got), StrG1(expected))
+			}
+		})
+	}
+}
+
+func TestReferenceBackendG1(t *testing.T) {
+	ref, err := GetBackend(ReferenceBackendName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	native := DefaultBackend()
+	var a, b G1Point
+	MulG1(&a, &GenG1, RandomFr())
+	MulG1(&b, &GenG1, RandomFr())
+	var got, expected G1Point
+	ref.AddG1(&got, &a, &b)
+	native.AddG1(&expected, &a, &b)
+	if !EqualG1(&got, &expected) {
+		t.Errorf("AddG1: got %s, expected %s", StrG1(&got), StrG1(&expected))
+	}
+	ref.AddG1(&got, &a, &a)
+	native.AddG1(&expected, &a, &a)
+	if !EqualG1(&got, &expected) {
+		t.Errorf("AddG1 doubling: got %s, expected %s", StrG1(&got), StrG1(&expected))
+	}
+	ref.SubG1(&got, &a, &b)
+	native.SubG1(&expected, &a, &b)
+	if !EqualG1(&got, &expected) {
+		t.Errorf("SubG1: got %s, expected %s", StrG1(&got), StrG1(&expected))
+	}
+	if !ref.EqualG1(&a, &a) || ref.EqualG1(&a, &b) || ref.EqualG1(&a, &ZeroG1) || !ref.EqualG1(&ZeroG1, &ZeroG1) {
+		t.Error("EqualG1 disagrees with the native backend")
+	}
+	// (r-1)*a + a is the point at infinity
+	ref.MulG1(&got, &a, &MODULUS_MINUS1)
+	ref.AddG1(&expected, &got, &a)
+	if !EqualG1(&expected, &ZeroG1) {
+		t.Errorf("(r-1)*a + a: got %s, expected the point at infinity", StrG1(&expected))
+	}
+	ref.SubG1(&got, &ZeroG1, &a)
+	native.MulG1(&expected, &a, &MODULUS_MINUS1)
+	if !EqualG1(&got, &expected) {
+		t.Errorf("0 - a: got %s, expected %s", StrG1(&got), StrG1(&expected))
+	}
+}
@@ -0,0 +1,75 @@
+package ff
+
+import "fmt"
+
+// Below this many values per worker, ParallelBatchInvModFr does not split up the work further.
+const minBatchInvChunk = 256
+
+// BatchInvModFr sets dst[i] to the inverse of values[i], using Montgomery's trick:
+// one inversion and 3*(n-1) multiplications for n values.
+// Zero has no inverse: if skipZeros is true, zero values are mapped to zero,
+// otherwise an error is returned, and dst is left untouched.
+// dst and values must have the same length, and may be the same slice.
+func BatchInvModFr(dst []Fr, values []Fr, skipZeros bool) error {
+	if len(dst) != len(values) {
+		return fmt.Errorf("dst length %d does not match values length %d", len(dst), len(values))
+	}
+	n := len(values)
+	if n == 0 {
+		return nil
+	}
+	// partials[i] = product of the non-zero values[0:i+1]
+	partials := make([]Fr, n, n)
+	var acc Fr
+	CopyFr(&acc, &ONE)
+	for i := 0; i < n; i++ {
+		if EqualZero(&values[i]) {
+			if !skipZeros {
+				return fmt.Errorf("cannot invert zero value at index %d", i)
+			}
+		} else {
+			MulModFr(&acc, &acc, &values[i])
+		}
+		CopyFr(&partials[i], &acc)
+	}
+	var inv Fr
+	InvModFr(&inv, &acc)
+	// walk back, inv is the inverse of partials[i] at the start of each iteration
+	var v Fr
+	for i := n - 1; i >= 0; i-- {
+		if EqualZero(&values[i]) {
+			CopyFr(&dst[i], &ZERO)
+			continue
+		}
+		CopyFr(&v, &values[i])
+		if i == 0 {
+			CopyFr(&dst[i], &inv)
+		} else {
+			MulModFr(&dst[i], &partials[i-1], &inv)
+		}
+		MulModFr(&inv, &inv, &v)
+	}
+	return nil
+}
+
+// ParallelBatchInvModFr is like BatchInvModFr, but splits the values into chunks that are inverted
+// concurrently, each with its own inversion. If workers <= 0, runtime.NumCPU() workers are used.
+// Small inputs are inverted on the calling goroutine.
+func ParallelBatchInvModFr(dst []Fr, values []Fr, skipZeros bool, workers int) error {
+	if len(dst) != len(values) {
+		return fmt.Errorf("dst length %d does not match values length %d", len(dst), len(values))
+	}
+	if !skipZeros {
+		// check upfront, so dst is left untouched on error, like BatchInvModFr does
+		for i := range values {
+			if EqualZero(&values[i]) {
+				return fmt.Errorf("cannot invert zero value at index %d", i)
+			}
+		}
+	}
+	ParallelRange(len(values), workers, minBatchInvChunk, func(start, end int) {
+		// zeros were checked for already, skipping them cannot change the result
+		_ = BatchInvModFr(dst[start:end], values[start:end], true)
+	})
+	return nil
+}
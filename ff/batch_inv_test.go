@@ -0,0 +1,69 @@
+package ff
+
+import "testing"
+
+func TestBatchInvModFr(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 1000} {
+		values := make([]Fr, n, n)
+		for i := range values {
+			CopyFr(&values[i], RandomFr())
+		}
+		checkInverses := func(name string, dst []Fr) {
+			var tmp Fr
+			for i := range values {
+				if EqualZero(&values[i]) {
+					if !EqualZero(&dst[i]) {
+						t.Errorf("%s n=%d: expected zero at %d, got %s", name, n, i, FrStr(&dst[i]))
+					}
+					continue
+				}
+				MulModFr(&tmp, &dst[i], &values[i])
+				if !EqualOne(&tmp) {
+					t.Errorf("%s n=%d: bad inverse at %d", name, n, i)
+				}
+			}
+		}
+		dst := make([]Fr, n, n)
+		if err := BatchInvModFr(dst, values, false); err != nil {
+			t.Fatal(err)
+		}
+		checkInverses("serial", dst)
+		if err := ParallelBatchInvModFr(dst, values, false, 3); err != nil {
+			t.Fatal(err)
+		}
+		checkInverses("parallel", dst)
+
+		if n < 2 {
+			continue
+		}
+		CopyFr(&values[0], &ZERO)
+		CopyFr(&values[n-1], &ZERO)
+		if err := BatchInvModFr(dst, values, false); err == nil {
+			t.Fatalf("n=%d: expected error for zero value", n)
+		}
+		if err := ParallelBatchInvModFr(dst, values, false, 3); err == nil {
+			t.Fatalf("n=%d: expected error for zero value", n)
+		}
+		if err := BatchInvModFr(dst, values, true); err != nil {
+			t.Fatal(err)
+		}
+		checkInverses("serial skip zeros", dst)
+		if err := ParallelBatchInvModFr(dst, values, true, 3); err != nil {
+			t.Fatal(err)
+		}
+		checkInverses("parallel skip zeros", dst)
+
+		// in-place
+		inPlace := make([]Fr, n, n)
+		for i := range values {
+			CopyFr(&inPlace[i], &values[i])
+		}
+		if err := BatchInvModFr(inPlace, inPlace, true); err != nil {
+			t.Fatal(err)
+		}
+		checkInverses("in-place", inPlace)
+	}
+	if err := BatchInvModFr(make([]Fr, 2), make([]Fr, 3), true); err == nil {
+		t.Fatal("expected length mismatch error")
+	}
+}
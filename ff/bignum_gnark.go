@@ -0,0 +1,154 @@
+// +build bignum_gnark
+
+package ff
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// BackendName is the name the build-tag selected backend is registered with, see DefaultBackend.
+const BackendName = "gnark"
+
+func init() {
+	if err := SetCurve(BLS12_381); err != nil {
+		panic(err)
+	}
+}
+
+func setBackendCurve(c CurveID) error {
+	if c != BLS12_381 {
+		return fmt.Errorf("the %s backend only supports %s, not %s", BackendName, BLS12_381, c)
+	}
+	return nil
+}
+
+// Fr is a gnark-crypto fr.Element, so values can be cast to and from (*fr.Element) without copying.
+type Fr fr.Element
+
+// frToBig converts the fr number into a new big.Int, in regular (non-montgomery) form.
+func frToBig(v *Fr) *big.Int {
+	var out big.Int
+	return (*fr.Element)(v).BigInt(&out)
+}
+
+func SetFr(dst *Fr, v string) {
+	if _, err := (*fr.Element)(dst).SetString(v); err != nil {
+		panic(err)
+	}
+}
+
+// FrFrom32 mutates the fr num. The value v is little-endian 32-bytes.
+func FrFrom32(dst *Fr, v [32]byte) {
+	// reverse endianness, gnark takes big-endian bytes
+	for i := 0; i < 16; i++ {
+		v[i], v[31-i] = v[31-i], v[i]
+	}
+	(*fr.Element)(dst).SetBytes(v[:])
+}
+
+// FrTo32 serializes a fr number to 32 bytes. Encoded little-endian.
+func FrTo32(src *Fr) (v [32]byte) {
+	v = (*fr.Element)(src).Bytes()
+	// reverse endianness, gnark outputs big-endian bytes
+	for i := 0; i < 16; i++ {
+		v[i], v[31-i] = v[31-i], v[i]
+	}
+	return
+}
+
+func CopyFr(dst *Fr, v *Fr) {
+	*dst = *v
+}
+
+func AsFr(dst *Fr, i uint64) {
+	(*fr.Element)(dst).SetUint64(i)
+}
+
+func FrStr(b *Fr) string {
+	if b == nil {
+		return "<nil>"
+	}
+	// fr.Element.String prints values close to r as negative numbers, print the canonical value like mcl does
+	return frToBig(b).String()
+}
+
+func EqualOne(v *Fr) bool {
+	return (*fr.Element)(v).IsOne()
+}
+
+func EqualZero(v *Fr) bool {
+	return (*fr.Element)(v).IsZero()
+}
+
+func EqualFr(a *Fr, b *Fr) bool {
+	return (*fr.Element)(a).Equal((*fr.Element)(b))
+}
+
+func RandomFr() *Fr {
+	var out fr.Element
+	if _, err := out.SetRandom(); err != nil {
+		panic(err)
+	}
+	return (*Fr)(&out)
+}
+
+func SubModFr(dst *Fr, a, b *Fr) {
+	(*fr.Element)(dst).Sub((*fr.Element)(a), (*fr.Element)(b))
+}
+
+func AddModFr(dst *Fr, a, b *Fr) {
+	(*fr.Element)(dst).Add((*fr.Element)(a), (*fr.Element)(b))
+}
+
+func DivModFr(dst *Fr, a, b *Fr) {
+	(*fr.Element)(dst).Div((*fr.Element)(a), (*fr.Element)(b))
+}
+
+func MulModFr(dst *Fr, a, b *Fr) {
+	(*fr.Element)(dst).Mul((*fr.Element)(a), (*fr.Element)(b))
+}
+
+func InvModFr(dst *Fr, v *Fr) {
+	(*fr.Element)(dst).Inverse((*fr.Element)(v))
+}
+
+func NegModFr(dst *Fr, v *Fr) {
+	(*fr.Element)(dst).Neg((*fr.Element)(v))
+}
+
+func SqrModFr(dst *Fr, v *Fr) {
+	(*fr.Element)(dst).Square((*fr.Element)(v))
+}
+
+func EvalPolyAt(dst *Fr, p []Fr, x *Fr) {
+	if len(p) == 0 {
+		panic("cannot evaluate polynomial without coefficients")
+	}
+	EvalPolyAtUnoptimized(dst, p, x)
+}
+
+func IntAsFr(dst *Fr, i int64) {
+	(*fr.Element)(dst).SetInt64(i)
+}
+
+func FromInt64Vec(in []int64) []Fr {
+	n := len(in)
+	dst := make([]Fr, n, n)
+	for i := 0; i < n; i++ {
+		(*fr.Element)(&dst[i]).SetInt64(in[i])
+	}
+	return dst
+}
+
+// MulVecFr returns the element-wise product of a and b, see HadamardVecFr.
+// It panics if the lengths differ, use HadamardVecFr to get an error instead.
+func MulVecFr(a, b []Fr) []Fr {
+	result := make([]Fr, len(a), len(a))
+	if err := HadamardVecFr(result, a, b); err != nil {
+		panic(err)
+	}
+	return result
+}
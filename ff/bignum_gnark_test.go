@@ -0,0 +1,22 @@
+// +build bignum_gnark
+
+package ff
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestFrIsGnarkElement(t *testing.T) {
+	a := RandomFr()
+	b := RandomFr()
+	var expected Fr
+	MulModFr(&expected, a, b)
+
+	var got fr.Element
+	got.Mul((*fr.Element)(a), (*fr.Element)(b))
+	if !EqualFr((*Fr)(&got), &expected) {
+		t.Fatalf("got %s, expected %s", got.String(), FrStr(&expected))
+	}
+}
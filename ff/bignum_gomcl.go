@@ -1,18 +1,37 @@
-// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls,!bignum_gnark
 
 package ff
 
 import (
+	"encoding/hex"
+	"fmt"
+	"strings"
 	"unsafe"
 
 	gmcl "github.com/alinush/go-mcl"
 )
 
+// BackendName is the name the build-tag selected backend is registered with, see DefaultBackend.
+const BackendName = "gomcl"
+
 func init() {
-	gmcl.InitFromString("bls12-381")
-	initGlobals()
-	ClearG1(&ZERO_G1)
-	initG1G2()
+	if err := SetCurve(BLS12_381); err != nil {
+		panic(err)
+	}
+}
+
+func setBackendCurve(c CurveID) error {
+	switch c {
+	case BLS12_381:
+		// the point encodings do not depend on the serialization and order check settings of mcl,
+		// so they are left alone for other users of mcl in the process, see ff_gomcl.go
+		gmcl.InitFromString("bls12-381")
+	case BN254:
+		gmcl.InitFromString("bn254_snark")
+	default:
+		return fmt.Errorf("the %s backend does not support %s", BackendName, c)
+	}
+	return nil
 }
 
 type Fr gmcl.Fr
@@ -30,13 +49,19 @@ func FrFrom32(dst *Fr, v [32]byte) {
 
 // FrTo32 serializes a fr number to 32 bytes. Encoded little-endian.
 func FrTo32(src *Fr) (v [32]byte) {
-	b := (*gmcl.Fr)(src).Serialize()
-	last := len(b) - 1
-	// reverse endianness, Herumi outputs big-endian bytes
-	for i := 0; i < 16; i++ {
-		b[i], b[last-i] = b[last-i], b[i]
+	// The byte order of Serialize depends on the global ETH serialization mode, which differs per curve,
+	// and may be changed by other users of mcl. The hex string is always big-endian.
+	h := strings.TrimPrefix((*gmcl.Fr)(src).GetString(16), "0x")
+	if len(h)%2 == 1 {
+		h = "0" + h
+	}
+	b, err := hex.DecodeString(h)
+	if err != nil || len(b) > 32 {
+		panic(fmt.Sprintf("unexpected Fr hex string %q", h))
+	}
+	for i := range b {
+		v[i] = b[len(b)-1-i]
 	}
-	copy(v[:], b)
 	return
 }
 
@@ -97,9 +122,9 @@ func NegModFr(dst *Fr, v *Fr) {
 	gmcl.FrNeg((*gmcl.Fr)(dst), (*gmcl.Fr)(v))
 }
 
-//func SqrModFr(dst *Fr, v *Fr) {
-//	gmcl.FrSqr((*gmcl.Fr)(dst), (*gmcl.Fr)(v))
-//}
+func SqrModFr(dst *Fr, v *Fr) {
+	gmcl.FrSqr((*gmcl.Fr)(dst), (*gmcl.Fr)(v))
+}
 
 func EvalPolyAt(dst *Fr, p []Fr, x *Fr) {
 	if err := gmcl.FrEvaluatePolynomial(
@@ -124,16 +149,12 @@ func FromInt64Vec(in []int64) []Fr {
 	return dst
 }
 
+// MulVecFr returns the element-wise product of a and b, see HadamardVecFr.
+// It panics if the lengths differ, use HadamardVecFr to get an error instead.
 func MulVecFr(a, b []Fr) []Fr {
-
-	n := len(a)
-	if n == len(b) && n > 0 {
-		result := make([]Fr, n, n)
-		for i := 0; i < n; i++ {
-			MulModFr(&result[i], &a[i], &b[i])
-		}
-		return result
+	result := make([]Fr, len(a), len(a))
+	if err := HadamardVecFr(result, a, b); err != nil {
+		panic(err)
 	}
-	result := make([]Fr, 0)
 	return result
 }
@@ -0,0 +1,38 @@
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls,!bignum_gnark
+
+package ff
+
+import (
+	"encoding/hex"
+	"testing"
+
+	gmcl "github.com/alinush/go-mcl"
+)
+
+// Other users of mcl in the process may change its serialization mode, the point encodings must not depend on it.
+func TestPointEncodingIgnoresMclSerializationMode(t *testing.T) {
+	var p G1Point
+	MulG1(&p, &GenG1, RandomFr())
+	var q G2Point
+	MulG2(&q, &GenG2, RandomFr())
+	expectedG1 := G1ToUncompressed(&p)
+	expectedG2 := G2ToUncompressed(&q)
+	for _, eth := range []bool{false, true} {
+		gmcl.SetETHserialization(eth)
+		gen := G1ToCompressed(&GenG1)
+		if got := hex.EncodeToString(gen[:]); got != genG1CompressedHex {
+			t.Errorf("ETH mode %v: got generator %s, expected %s", eth, got, genG1CompressedHex)
+		}
+		if G1ToUncompressed(&p) != expectedG1 || G2ToUncompressed(&q) != expectedG2 {
+			t.Errorf("ETH mode %v: the encoding changed", eth)
+		}
+		var gotP G1Point
+		if err := G1FromUncompressed(&gotP, expectedG1[:]); err != nil || !EqualG1(&gotP, &p) {
+			t.Errorf("ETH mode %v: G1 decoding failed: %v", eth, err)
+		}
+		var gotQ G2Point
+		if err := G2FromUncompressed(&gotQ, expectedG2[:]); err != nil || !EqualG2(&gotQ, &q) {
+			t.Errorf("ETH mode %v: G2 decoding failed: %v", eth, err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// +build bignum_hbls
+
+package ff
+
+import (
+	"fmt"
+	"unsafe"
+
+	hbls "github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// BackendName is the name the build-tag selected backend is registered with, see DefaultBackend.
+const BackendName = "hbls"
+
+func init() {
+	if err := SetCurve(BLS12_381); err != nil {
+		panic(err)
+	}
+}
+
+func setBackendCurve(c CurveID) error {
+	if c != BLS12_381 {
+		return fmt.Errorf("the %s backend only supports %s, not %s", BackendName, BLS12_381, c)
+	}
+	if err := hbls.Init(hbls.BLS12_381); err != nil {
+		return err
+	}
+	// use the ZCash point format, and leave subgroup checks to the G1/G2 decoders, see point_encoding.go
+	hbls.SetETHserialization(true)
+	hbls.VerifyOrderG1(false)
+	hbls.VerifyOrderG2(false)
+	return nil
+}
+
+type Fr hbls.Fr
+
+func SetFr(dst *Fr, v string) {
+	if err := (*hbls.Fr)(dst).SetString(v, 10); err != nil {
+		panic(err)
+	}
+}
+
+// FrFrom32 mutates the fr num. The value v is little-endian 32-bytes.
+func FrFrom32(dst *Fr, v [32]byte) {
+	(*hbls.Fr)(dst).SetLittleEndian(v[:])
+}
+
+// FrTo32 serializes a fr number to 32 bytes. Encoded little-endian.
+func FrTo32(src *Fr) (v [32]byte) {
+	b := (*hbls.Fr)(src).Serialize()
+	last := len(b) - 1
+	// reverse endianness, Herumi outputs big-endian bytes
+	for i := 0; i < 16; i++ {
+		b[i], b[last-i] = b[last-i], b[i]
+	}
+	copy(v[:], b)
+	return
+}
+
+func CopyFr(dst *Fr, v *Fr) {
+	*dst = *v
+}
+
+func AsFr(dst *Fr, i uint64) {
+	(*hbls.Fr)(dst).SetInt64(int64(i))
+}
+
+func FrStr(b *Fr) string {
+	if b == nil {
+		return "<nil>"
+	}
+	return (*hbls.Fr)(b).GetString(10)
+}
+
+func EqualOne(v *Fr) bool {
+	return (*hbls.Fr)(v).IsOne()
+}
+
+func EqualZero(v *Fr) bool {
+	return (*hbls.Fr)(v).IsZero()
+}
+
+func EqualFr(a *Fr, b *Fr) bool {
+	return (*hbls.Fr)(a).IsEqual((*hbls.Fr)(b))
+}
+
+func RandomFr() *Fr {
+	var out hbls.Fr
+	out.SetByCSPRNG()
+	return (*Fr)(&out)
+}
+
+func SubModFr(dst *Fr, a, b *Fr) {
+	hbls.FrSub((*hbls.Fr)(dst), (*hbls.Fr)(a), (*hbls.Fr)(b))
+}
+
+func AddModFr(dst *Fr, a, b *Fr) {
+	hbls.FrAdd((*hbls.Fr)(dst), (*hbls.Fr)(a), (*hbls.Fr)(b))
+}
+
+func DivModFr(dst *Fr, a, b *Fr) {
+	hbls.FrDiv((*hbls.Fr)(dst), (*hbls.Fr)(a), (*hbls.Fr)(b))
+}
+
+func MulModFr(dst *Fr, a, b *Fr) {
+	hbls.FrMul((*hbls.Fr)(dst), (*hbls.Fr)(a), (*hbls.Fr)(b))
+}
+
+func InvModFr(dst *Fr, v *Fr) {
+	hbls.FrInv((*hbls.Fr)(dst), (*hbls.Fr)(v))
+}
+
+func NegModFr(dst *Fr, v *Fr) {
+	hbls.FrNeg((*hbls.Fr)(dst), (*hbls.Fr)(v))
+}
+
+func SqrModFr(dst *Fr, v *Fr) {
+	hbls.FrSqr((*hbls.Fr)(dst), (*hbls.Fr)(v))
+}
+
+func EvalPolyAt(dst *Fr, p []Fr, x *Fr) {
+	if err := hbls.FrEvaluatePolynomial(
+		(*hbls.Fr)(dst),
+		*(*[]hbls.Fr)(unsafe.Pointer(&p)),
+		(*hbls.Fr)(x),
+	); err != nil {
+		panic(err) // TODO: why does the herumi API return an error? When coefficients are empty?
+	}
+}
+
+func IntAsFr(dst *Fr, i int64) {
+	(*hbls.Fr)(dst).SetInt64(i)
+}
+
+func FromInt64Vec(in []int64) []Fr {
+	n := len(in)
+	dst := make([]Fr, n, n)
+	for i := 0; i < n; i++ {
+		(*hbls.Fr)(&dst[i]).SetInt64(in[i])
+	}
+	return dst
+}
+
+// MulVecFr returns the element-wise product of a and b, see HadamardVecFr.
+// It panics if the lengths differ, use HadamardVecFr to get an error instead.
+func MulVecFr(a, b []Fr) []Fr {
+	result := make([]Fr, len(a), len(a))
+	if err := HadamardVecFr(result, a, b); err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+// +build bignum_hbls
+
+package ff
+
+import (
+	"testing"
+
+	hbls "github.com/herumi/bls-eth-go-binary/bls"
+)
+
+// Applications linking herumi sign with its global hash settings, HashToG1 and HashToG2 must not change them.
+func TestHashToCurveKeepsHerumiSettings(t *testing.T) {
+	msg := []byte("message")
+	var before, after hbls.G2
+	if err := before.HashAndMapTo(msg); err != nil {
+		t.Fatal(err)
+	}
+	var p1 G1Point
+	if err := HashToG1(&p1, msg, []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")); err != nil {
+		t.Fatal(err)
+	}
+	var p2 G2Point
+	if err := HashToG2(&p2, msg, []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")); err != nil {
+		t.Fatal(err)
+	}
+	if err := after.HashAndMapTo(msg); err != nil {
+		t.Fatal(err)
+	}
+	if !before.IsEqual(&after) {
+		t.Fatal("the herumi hash to curve settings changed")
+	}
+}
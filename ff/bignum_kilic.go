@@ -0,0 +1,174 @@
+// +build bignum_kilic
+
+package ff
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	kbls "github.com/kilic/bls12-381"
+)
+
+// BackendName is the name the build-tag selected backend is registered with, see DefaultBackend.
+const BackendName = "kilic"
+
+func init() {
+	if err := SetCurve(BLS12_381); err != nil {
+		panic(err)
+	}
+}
+
+func setBackendCurve(c CurveID) error {
+	if c != BLS12_381 {
+		return fmt.Errorf("the %s backend only supports %s, not %s", BackendName, BLS12_381, c)
+	}
+	return nil
+}
+
+// The BLS12-381 scalar field order r.
+var frModulus = kbls.NewG1().Q()
+
+// Fr is a kilic fr element, in regular (non-montgomery) form.
+type Fr kbls.Fr
+
+// frToBig converts the fr number into a new big.Int.
+func frToBig(v *Fr) *big.Int {
+	return (*kbls.Fr)(v).ToBig()
+}
+
+func SetFr(dst *Fr, v string) {
+	var b big.Int
+	if _, ok := b.SetString(v, 10); !ok {
+		panic("failed to parse fr number: " + v)
+	}
+	b.Mod(&b, frModulus)
+	(*kbls.Fr)(dst).FromBytes(b.Bytes())
+}
+
+// FrFrom32 mutates the fr num. The value v is little-endian 32-bytes.
+func FrFrom32(dst *Fr, v [32]byte) {
+	// reverse endianness, kilic takes big-endian bytes
+	for i := 0; i < 16; i++ {
+		v[i], v[31-i] = v[31-i], v[i]
+	}
+	// kilic only reduces values larger than the modulus, so reduce here to handle the modulus itself too.
+	var b big.Int
+	b.SetBytes(v[:])
+	b.Mod(&b, frModulus)
+	(*kbls.Fr)(dst).FromBytes(b.Bytes())
+}
+
+// FrTo32 serializes a fr number to 32 bytes. Encoded little-endian.
+func FrTo32(src *Fr) (v [32]byte) {
+	b := (*kbls.Fr)(src).ToBytes()
+	last := len(b) - 1
+	// reverse endianness, kilic outputs big-endian bytes
+	for i := 0; i < 16; i++ {
+		b[i], b[last-i] = b[last-i], b[i]
+	}
+	copy(v[:], b)
+	return
+}
+
+func CopyFr(dst *Fr, v *Fr) {
+	*dst = *v
+}
+
+func AsFr(dst *Fr, i uint64) {
+	// any uint64 is smaller than the modulus, no reduction needed
+	*dst = Fr{i, 0, 0, 0}
+}
+
+func FrStr(b *Fr) string {
+	if b == nil {
+		return "<nil>"
+	}
+	return (*kbls.Fr)(b).ToBig().String()
+}
+
+func EqualOne(v *Fr) bool {
+	return (*kbls.Fr)(v).IsOne()
+}
+
+func EqualZero(v *Fr) bool {
+	return (*kbls.Fr)(v).IsZero()
+}
+
+func EqualFr(a *Fr, b *Fr) bool {
+	return (*kbls.Fr)(a).Equal((*kbls.Fr)(b))
+}
+
+func RandomFr() *Fr {
+	out, err := kbls.NewFr().Rand(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return (*Fr)(out)
+}
+
+func SubModFr(dst *Fr, a, b *Fr) {
+	(*kbls.Fr)(dst).Sub((*kbls.Fr)(a), (*kbls.Fr)(b))
+}
+
+func AddModFr(dst *Fr, a, b *Fr) {
+	(*kbls.Fr)(dst).Add((*kbls.Fr)(a), (*kbls.Fr)(b))
+}
+
+func DivModFr(dst *Fr, a, b *Fr) {
+	var tmp kbls.Fr
+	tmp.Inverse((*kbls.Fr)(b))
+	(*kbls.Fr)(dst).Mul(&tmp, (*kbls.Fr)(a))
+}
+
+func MulModFr(dst *Fr, a, b *Fr) {
+	(*kbls.Fr)(dst).Mul((*kbls.Fr)(a), (*kbls.Fr)(b))
+}
+
+func InvModFr(dst *Fr, v *Fr) {
+	(*kbls.Fr)(dst).Inverse((*kbls.Fr)(v))
+}
+
+func NegModFr(dst *Fr, v *Fr) {
+	(*kbls.Fr)(dst).Neg((*kbls.Fr)(v))
+}
+
+func SqrModFr(dst *Fr, v *Fr) {
+	(*kbls.Fr)(dst).Square((*kbls.Fr)(v))
+}
+
+func EvalPolyAt(dst *Fr, p []Fr, x *Fr) {
+	if len(p) == 0 {
+		panic("cannot evaluate polynomial without coefficients")
+	}
+	EvalPolyAtUnoptimized(dst, p, x)
+}
+
+func IntAsFr(dst *Fr, i int64) {
+	if i < 0 {
+		// -i does not fit for the minimum int64, but as uint64 it is exact.
+		AsFr(dst, uint64(-i))
+		NegModFr(dst, dst)
+		return
+	}
+	AsFr(dst, uint64(i))
+}
+
+func FromInt64Vec(in []int64) []Fr {
+	n := len(in)
+	dst := make([]Fr, n, n)
+	for i := 0; i < n; i++ {
+		IntAsFr(&dst[i], in[i])
+	}
+	return dst
+}
+
+// MulVecFr returns the element-wise product of a and b, see HadamardVecFr.
+// It panics if the lengths differ, use HadamardVecFr to get an error instead.
+func MulVecFr(a, b []Fr) []Fr {
+	result := make([]Fr, len(a), len(a))
+	if err := HadamardVecFr(result, a, b); err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+// +build bignum_pure
+
+package ff
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// BackendName is the name the build-tag selected backend is registered with, see DefaultBackend.
+const BackendName = "pure"
+
+func init() {
+	if err := SetCurve(BLS12_381); err != nil {
+		panic(err)
+	}
+}
+
+// Any curve is supported for Fr, only the modulus changes.
+// The G1 and G2 operations remain BLS12-381 however, see ff_kilic_points.go.
+func setBackendCurve(c CurveID) error {
+	params, ok := curves[c]
+	if !ok {
+		return fmt.Errorf("unknown curve: %s", c)
+	}
+	if _, ok := frModulus.SetString(params.modulus, 10); !ok {
+		panic("bad modulus for curve " + params.name)
+	}
+	return nil
+}
+
+// The scalar field order r of the current curve.
+var frModulus big.Int
+
+// Fr is a math/big integer, always kept reduced in the range [0, r).
+type Fr big.Int
+
+// Results are always computed into a fresh big.Int and then assigned to dst.
+// Fr values are copied around by value in various places (e.g. out[i] = ff.ONE),
+// which shares the underlying words, so they must never be mutated in place.
+func setFrBig(dst *Fr, v *big.Int) {
+	*(*big.Int)(dst) = *v
+}
+
+// frToBig returns the big.Int view of the fr number. The result must not be mutated.
+func frToBig(v *Fr) *big.Int {
+	return (*big.Int)(v)
+}
+
+func SetFr(dst *Fr, v string) {
+	var out big.Int
+	if _, ok := out.SetString(v, 10); !ok {
+		panic("failed to parse fr number: " + v)
+	}
+	out.Mod(&out, &frModulus)
+	setFrBig(dst, &out)
+}
+
+// FrFrom32 mutates the fr num. The value v is little-endian 32-bytes.
+func FrFrom32(dst *Fr, v [32]byte) {
+	// reverse endianness, big.Int takes big-endian bytes
+	for i := 0; i < 16; i++ {
+		v[i], v[31-i] = v[31-i], v[i]
+	}
+	var out big.Int
+	out.SetBytes(v[:])
+	out.Mod(&out, &frModulus)
+	setFrBig(dst, &out)
+}
+
+// FrTo32 serializes a fr number to 32 bytes. Encoded little-endian.
+func FrTo32(src *Fr) (v [32]byte) {
+	(*big.Int)(src).FillBytes(v[:])
+	// reverse endianness, big.Int outputs big-endian bytes
+	for i := 0; i < 16; i++ {
+		v[i], v[31-i] = v[31-i], v[i]
+	}
+	return
+}
+
+func CopyFr(dst *Fr, v *Fr) {
+	var out big.Int
+	out.Set((*big.Int)(v))
+	setFrBig(dst, &out)
+}
+
+func AsFr(dst *Fr, i uint64) {
+	var out big.Int
+	out.SetUint64(i)
+	out.Mod(&out, &frModulus)
+	setFrBig(dst, &out)
+}
+
+func FrStr(b *Fr) string {
+	if b == nil {
+		return "<nil>"
+	}
+	return (*big.Int)(b).String()
+}
+
+func EqualOne(v *Fr) bool {
+	return (*big.Int)(v).Cmp(big.NewInt(1)) == 0
+}
+
+func EqualZero(v *Fr) bool {
+	return (*big.Int)(v).Sign() == 0
+}
+
+func EqualFr(a *Fr, b *Fr) bool {
+	return (*big.Int)(a).Cmp((*big.Int)(b)) == 0
+}
+
+func RandomFr() *Fr {
+	v, err := rand.Int(rand.Reader, &frModulus)
+	if err != nil {
+		panic(err)
+	}
+	return (*Fr)(v)
+}
+
+func SubModFr(dst *Fr, a, b *Fr) {
+	var out big.Int
+	out.Sub((*big.Int)(a), (*big.Int)(b))
+	out.Mod(&out, &frModulus)
+	setFrBig(dst, &out)
+}
+
+func AddModFr(dst *Fr, a, b *Fr) {
+	var out big.Int
+	out.Add((*big.Int)(a), (*big.Int)(b))
+	out.Mod(&out, &frModulus)
+	setFrBig(dst, &out)
+}
+
+func DivModFr(dst *Fr, a, b *Fr) {
+	var tmp Fr
+	InvModFr(&tmp, b)
+	MulModFr(dst, a, &tmp)
+}
+
+func MulModFr(dst *Fr, a, b *Fr) {
+	var out big.Int
+	out.Mul((*big.Int)(a), (*big.Int)(b))
+	out.Mod(&out, &frModulus)
+	setFrBig(dst, &out)
+}
+
+// InvModFr sets dst to the inverse of v. Zero has no inverse, and is mapped to zero, like the other backends do.
+func InvModFr(dst *Fr, v *Fr) {
+	var out big.Int
+	if out.ModInverse((*big.Int)(v), &frModulus) == nil {
+		out.SetUint64(0)
+	}
+	setFrBig(dst, &out)
+}
+
+func NegModFr(dst *Fr, v *Fr) {
+	var out big.Int
+	out.Neg((*big.Int)(v))
+	out.Mod(&out, &frModulus)
+	setFrBig(dst, &out)
+}
+
+func SqrModFr(dst *Fr, v *Fr) {
+	var out big.Int
+	out.Mul((*big.Int)(v), (*big.Int)(v))
+	out.Mod(&out, &frModulus)
+	setFrBig(dst, &out)
+}
+
+func EvalPolyAt(dst *Fr, p []Fr, x *Fr) {
+	if len(p) == 0 {
+		panic("cannot evaluate polynomial without coefficients")
+	}
+	EvalPolyAtUnoptimized(dst, p, x)
+}
+
+func IntAsFr(dst *Fr, i int64) {
+	var out big.Int
+	out.SetInt64(i)
+	out.Mod(&out, &frModulus)
+	setFrBig(dst, &out)
+}
+
+func FromInt64Vec(in []int64) []Fr {
+	n := len(in)
+	dst := make([]Fr, n, n)
+	for i := 0; i < n; i++ {
+		IntAsFr(&dst[i], in[i])
+	}
+	return dst
+}
+
+// MulVecFr returns the element-wise product of a and b, see HadamardVecFr.
+// It panics if the lengths differ, use HadamardVecFr to get an error instead.
+func MulVecFr(a, b []Fr) []Fr {
+	result := make([]Fr, len(a), len(a))
+	if err := HadamardVecFr(result, a, b); err != nil {
+		panic(err)
+	}
+	return result
+}
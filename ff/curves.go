@@ -0,0 +1,79 @@
+package ff
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// CurveID identifies a pairing-friendly curve, and thereby the scalar field Fr.
+type CurveID uint8
+
+const (
+	BLS12_381 CurveID = iota
+	// BN254 is the curve of the Ethereum pairing precompiles, also known as alt_bn128 or bn254_snark.
+	BN254
+)
+
+type curveParams struct {
+	name string
+	// the scalar field modulus r, in decimal
+	modulus string
+	// generator of the multiplicative group of Fr, the roots of unity are derived from it
+	primitiveRoot uint64
+}
+
+var curves = map[CurveID]*curveParams{
+	BLS12_381: {
+		name:          "BLS12-381",
+		modulus:       "52435875175126190479447740508185965837690552500527637822603658699938581184513",
+		primitiveRoot: 5,
+	},
+	BN254: {
+		name:          "BN254",
+		modulus:       "21888242871839275222246405745257275088548364400416034343698204186575808495617",
+		primitiveRoot: 5,
+	},
+}
+
+func (c CurveID) String() string {
+	if p, ok := curves[c]; ok {
+		return p.name
+	}
+	return fmt.Sprintf("CurveID(%d)", uint8(c))
+}
+
+var currentCurve CurveID
+var currentModulus big.Int
+
+// CurrentCurve returns the curve that Fr, G1Point and G2Point currently operate on.
+func CurrentCurve() CurveID {
+	return currentCurve
+}
+
+// FrModulus returns a copy of the scalar field modulus r of the current curve.
+func FrModulus() *big.Int {
+	return new(big.Int).Set(&currentModulus)
+}
+
+// SetCurve switches the backend to the given curve, and re-initializes the globals
+// (Scale2RootOfUnity, MODULUS_MINUS1, GenG1, etc.) for it.
+// Not every backend supports every curve, an error is returned if the backend doesn't.
+// This is not thread safe, and values (including FFTSettings) created for the previous curve must not be used anymore.
+func SetCurve(c CurveID) error {
+	params, ok := curves[c]
+	if !ok {
+		return fmt.Errorf("unknown curve: %s", c)
+	}
+	if err := setBackendCurve(c); err != nil {
+		return err
+	}
+	currentCurve = c
+	if _, ok := currentModulus.SetString(params.modulus, 10); !ok {
+		panic("bad modulus for curve " + params.name)
+	}
+	initGlobals()
+	ClearG1(&ZERO_G1)
+	initG1G2()
+	resetGenTables()
+	return nil
+}
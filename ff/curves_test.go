@@ -0,0 +1,101 @@
+package ff
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSetCurve(t *testing.T) {
+	if CurrentCurve() != BLS12_381 {
+		t.Fatalf("expected %s to be the default curve, got %s", BLS12_381, CurrentCurve())
+	}
+	if err := SetCurve(CurveID(100)); err == nil {
+		t.Fatal("expected error for unknown curve")
+	}
+	withCurves(t, func(t *testing.T, c CurveID) {
+		if CurrentCurve() != c {
+			t.Fatalf("got curve %s, expected %s", CurrentCurve(), c)
+		}
+		expected := new(big.Int).Sub(FrModulus(), big.NewInt(1))
+		if got := FrStr(&MODULUS_MINUS1); got != expected.String() {
+			t.Errorf("got r-1 = %s, expected %s", got, expected)
+		}
+		var tmp Fr
+		MulModFr(&tmp, &INVERSE_TWO, &TWO)
+		if !EqualOne(&tmp) {
+			t.Error("bad INVERSE_TWO")
+		}
+	})
+}
+
+// withCurves runs the test for each curve the backend supports, and switches back to BLS12-381 afterwards.
+func withCurves(t *testing.T, fn func(t *testing.T, c CurveID)) {
+	for _, c := range []CurveID{BLS12_381, BN254} {
+		t.Run(c.String(), func(t *testing.T) {
+			if err := SetCurve(c); err != nil {
+				t.Skip(err)
+			}
+			defer func() {
+				if err := SetCurve(BLS12_381); err != nil {
+					t.Fatal(err)
+				}
+			}()
+			fn(t, c)
+		})
+	}
+}
+
+func TestFrTo32Curves(t *testing.T) {
+	withCurves(t, func(t *testing.T, c CurveID) {
+		var small Fr
+		AsFr(&small, 0x0102)
+		values := []*Fr{&ZERO, &ONE, &small, &MODULUS_MINUS1, RandomFr(), RandomFr()}
+		for _, x := range values {
+			enc := FrTo32(x)
+			// the encoding is little-endian, whatever the curve or the backend's serialization mode
+			expected, ok := new(big.Int).SetString(FrStr(x), 10)
+			if !ok {
+				t.Fatalf("bad FrStr output %q", FrStr(x))
+			}
+			var le [32]byte
+			for i, v := range expected.FillBytes(make([]byte, 32)) {
+				le[31-i] = v
+			}
+			if enc != le {
+				t.Fatalf("FrTo32(%s) = %x, expected %x", FrStr(x), enc, le)
+			}
+			var got Fr
+			FrFrom32(&got, enc)
+			if !EqualFr(&got, x) {
+				t.Fatalf("FrFrom32(FrTo32(%s)) = %s", FrStr(x), FrStr(&got))
+			}
+		}
+	})
+}
+
+// The exponentiation and encoding helpers go through FrTo32, run them on every curve.
+func TestExpModFrCurves(t *testing.T) {
+	withCurves(t, func(t *testing.T, c CurveID) {
+		t.Run("ExpModFr", TestExpModFr)
+		t.Run("SqrtModFr", TestSqrtModFr)
+		t.Run("FrFromBytesCanonical", TestFrFromBytesCanonical)
+		// the roots of unity are derived from the primitive root, which is not a square
+		var g Fr
+		AsFr(&g, curves[c].primitiveRoot)
+		if LegendreFr(&g) != -1 {
+			t.Fatalf("expected primitive root %s to be a non-residue", FrStr(&g))
+		}
+		x := RandomFr()
+		for _, name := range BackendNames() {
+			b, err := GetBackend(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got Fr
+			b.FrFrom32(&got, b.FrTo32(x))
+			if !EqualFr(&got, x) {
+				t.Errorf("backend %s: FrFrom32(FrTo32(%s)) = %s", name, FrStr(x), FrStr(&got))
+			}
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package ff
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// FrSize is the size of an encoded Fr value in bytes.
+const FrSize = 32
+
+// ParseFr parses a decimal number, which must be in the range [0, r).
+// Unlike SetFr it returns an error instead of panicking, and does not reduce modulo r.
+func ParseFr(v string) (out Fr, err error) {
+	var b big.Int
+	if _, ok := b.SetString(v, 10); !ok {
+		return out, fmt.Errorf("failed to parse fr number: %q", v)
+	}
+	if err := checkCanonical(&b); err != nil {
+		return out, err
+	}
+	frFromBig(&out, &b)
+	return out, nil
+}
+
+// FrFromBytesCanonical decodes a 32 byte value, big-endian or little-endian,
+// and returns an error if it is not the canonical encoding of a field element, i.e. not in [0, r).
+// Unlike FrFrom32 it does not reduce modulo r.
+func FrFromBytesCanonical(b []byte, bigEndian bool) (out Fr, err error) {
+	if len(b) != FrSize {
+		return out, fmt.Errorf("expected %d bytes, got %d", FrSize, len(b))
+	}
+	var be [FrSize]byte
+	copy(be[:], b)
+	if !bigEndian {
+		reverse32(&be)
+	}
+	v := new(big.Int).SetBytes(be[:])
+	if err := checkCanonical(v); err != nil {
+		return out, err
+	}
+	frFromBig(&out, v)
+	return out, nil
+}
+
+// FrTo32BE serializes a fr number to 32 bytes. Encoded big-endian.
+func FrTo32BE(src *Fr) [32]byte {
+	v := FrTo32(src)
+	reverse32(&v)
+	return v
+}
+
+// FrFrom32BE mutates the fr num. The value v is big-endian 32-bytes, and is reduced modulo r, like FrFrom32.
+func FrFrom32BE(dst *Fr, v [32]byte) {
+	reverse32(&v)
+	FrFrom32(dst, v)
+}
+
+// FrToBytes encodes the fr number as 32 bytes, the inverse of FrFromBytesCanonical.
+func FrToBytes(src *Fr, bigEndian bool) []byte {
+	var v [32]byte
+	if bigEndian {
+		v = FrTo32BE(src)
+	} else {
+		v = FrTo32(src)
+	}
+	return v[:]
+}
+
+func checkCanonical(v *big.Int) error {
+	if v.Sign() < 0 {
+		return fmt.Errorf("fr number %s is negative", v)
+	}
+	if v.Cmp(&currentModulus) >= 0 {
+		return fmt.Errorf("fr number %s is not less than the modulus", v)
+	}
+	return nil
+}
+
+// frFromBig sets dst to v, which must be in [0, r).
+func frFromBig(dst *Fr, v *big.Int) {
+	var b [32]byte
+	v.FillBytes(b[:])
+	FrFrom32BE(dst, b)
+}
+
+func reverse32(v *[32]byte) {
+	for i := 0; i < 16; i++ {
+		v[i], v[31-i] = v[31-i], v[i]
+	}
+}
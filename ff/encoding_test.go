@@ -0,0 +1,66 @@
+package ff
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestParseFr(t *testing.T) {
+	x := RandomFr()
+	got, err := ParseFr(FrStr(x))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !EqualFr(&got, x) {
+		t.Fatalf("got %s, expected %s", FrStr(&got), FrStr(x))
+	}
+	rMinus1 := new(big.Int).Sub(FrModulus(), big.NewInt(1))
+	if got, err := ParseFr(rMinus1.String()); err != nil || !EqualFr(&got, &MODULUS_MINUS1) {
+		t.Fatalf("failed to parse r-1: %v", err)
+	}
+	for _, bad := range []string{"", "abc", "0x12", "-1", FrModulus().String(), new(big.Int).Lsh(big.NewInt(1), 256).String()} {
+		if _, err := ParseFr(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestFrFromBytesCanonical(t *testing.T) {
+	x := RandomFr()
+	for _, bigEndian := range []bool{false, true} {
+		enc := FrToBytes(x, bigEndian)
+		got, err := FrFromBytesCanonical(enc, bigEndian)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !EqualFr(&got, x) {
+			t.Fatalf("bigEndian=%v: got %s, expected %s", bigEndian, FrStr(&got), FrStr(x))
+		}
+	}
+	le := FrTo32(x)
+	be := FrTo32BE(x)
+	for i := 0; i < 32; i++ {
+		if le[i] != be[31-i] {
+			t.Fatal("big-endian encoding is not the reverse of the little-endian encoding")
+		}
+	}
+	var fromBE Fr
+	FrFrom32BE(&fromBE, be)
+	if !EqualFr(&fromBE, x) {
+		t.Fatal("FrFrom32BE does not invert FrTo32BE")
+	}
+
+	// r itself, and 2^256-1, are not canonical
+	var r [32]byte
+	FrModulus().FillBytes(r[:])
+	if _, err := FrFromBytesCanonical(r[:], true); err == nil {
+		t.Error("expected error for the modulus")
+	}
+	if _, err := FrFromBytesCanonical(bytes.Repeat([]byte{0xff}, 32), false); err == nil {
+		t.Error("expected error for 2^256-1")
+	}
+	if _, err := FrFromBytesCanonical(make([]byte, 31), false); err == nil {
+		t.Error("expected error for short input")
+	}
+}
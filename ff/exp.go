@@ -0,0 +1,90 @@
+package ff
+
+import "math/big"
+
+// expModFrBig sets dst to base^e, with left-to-right square-and-multiply. e must not be negative.
+func expModFrBig(dst *Fr, base *Fr, e *big.Int) {
+	var b, out Fr
+	CopyFr(&b, base) // base may alias dst
+	CopyFr(&out, &ONE)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		SqrModFr(&out, &out)
+		if e.Bit(i) == 1 {
+			MulModFr(&out, &out, &b)
+		}
+	}
+	CopyFr(dst, &out)
+}
+
+// ExpModFr sets dst to base^e, the exponent is the canonical integer value of e, in [0, r).
+func ExpModFr(dst *Fr, base *Fr, e *Fr) {
+	v := FrTo32BE(e)
+	expModFrBig(dst, base, new(big.Int).SetBytes(v[:]))
+}
+
+// ExpModFrUint64 sets dst to base^e.
+func ExpModFrUint64(dst *Fr, base *Fr, e uint64) {
+	expModFrBig(dst, base, new(big.Int).SetUint64(e))
+}
+
+// LegendreFr returns the Legendre symbol of v: 1 if v is a non-zero square, -1 if it is not a square, 0 if v is zero.
+// Computed with Euler's criterion, v^((r-1)/2).
+func LegendreFr(v *Fr) int {
+	if EqualZero(v) {
+		return 0
+	}
+	var tmp Fr
+	ExpModFr(&tmp, v, &MODULUS_MINUS1_DIV2)
+	if EqualOne(&tmp) {
+		return 1
+	}
+	return -1
+}
+
+// SqrtModFr sets dst to a square root of v, with the Tonelli-Shanks algorithm, and returns true.
+// If v is not a square, false is returned, and dst is left untouched.
+// The other square root is the negation of dst.
+func SqrtModFr(dst *Fr, v *Fr) bool {
+	switch LegendreFr(v) {
+	case 0:
+		CopyFr(dst, &ZERO)
+		return true
+	case -1:
+		return false
+	}
+	// r - 1 = q * 2^s, with q odd
+	s := TwoAdicity(&currentModulus)
+	q := new(big.Int).Sub(&currentModulus, big.NewInt(1))
+	q.Rsh(q, s)
+
+	// c = z^q for a non-residue z has exact order 2^s, this is the primitive 2^s-th root of unity
+	var c Fr
+	CopyFr(&c, &Scale2RootOfUnity[s])
+	// t = v^q, x = v^((q+1)/2)
+	var t, x Fr
+	expModFrBig(&t, v, q)
+	expModFrBig(&x, v, new(big.Int).Rsh(new(big.Int).Add(q, big.NewInt(1)), 1))
+
+	m := s
+	var tmp, b Fr
+	for !EqualOne(&t) {
+		// find the least i with t^(2^i) = 1, 0 < i < m
+		i := uint(0)
+		CopyFr(&tmp, &t)
+		for !EqualOne(&tmp) {
+			SqrModFr(&tmp, &tmp)
+			i++
+		}
+		// b = c^(2^(m-i-1))
+		CopyFr(&b, &c)
+		for j := uint(0); j < m-i-1; j++ {
+			SqrModFr(&b, &b)
+		}
+		m = i
+		SqrModFr(&c, &b)
+		MulModFr(&t, &t, &c)
+		MulModFr(&x, &x, &b)
+	}
+	CopyFr(dst, &x)
+	return true
+}
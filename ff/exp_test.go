@@ -0,0 +1,75 @@
+package ff
+
+import "testing"
+
+func TestExpModFr(t *testing.T) {
+	x := RandomFr()
+	var expected, got Fr
+	CopyFr(&expected, &ONE)
+	for e := uint64(0); e < 20; e++ {
+		ExpModFrUint64(&got, x, e)
+		if !EqualFr(&got, &expected) {
+			t.Fatalf("x^%d: got %s, expected %s", e, FrStr(&got), FrStr(&expected))
+		}
+		var eFr Fr
+		AsFr(&eFr, e)
+		ExpModFr(&got, x, &eFr)
+		if !EqualFr(&got, &expected) {
+			t.Fatalf("x^%d with Fr exponent: got %s, expected %s", e, FrStr(&got), FrStr(&expected))
+		}
+		MulModFr(&expected, &expected, x)
+	}
+	// Fermat: x^(r-1) = 1
+	ExpModFr(&got, x, &MODULUS_MINUS1)
+	if !EqualOne(&got) {
+		t.Fatalf("x^(r-1) is not 1: %s", FrStr(&got))
+	}
+	// aliasing
+	var sq Fr
+	SqrModFr(&sq, x)
+	CopyFr(&got, x)
+	ExpModFrUint64(&got, &got, 2)
+	if !EqualFr(&got, &sq) {
+		t.Fatalf("got %s, expected %s", FrStr(&got), FrStr(&sq))
+	}
+}
+
+func TestSqrtModFr(t *testing.T) {
+	if LegendreFr(&ZERO) != 0 {
+		t.Fatal("expected 0 for zero")
+	}
+	// 5 is the primitive root, and thereby not a square
+	if LegendreFr(&PRIMITIVE_ROOT) != -1 {
+		t.Fatal("expected the primitive root to be a non-residue")
+	}
+	var root Fr
+	if SqrtModFr(&root, &PRIMITIVE_ROOT) {
+		t.Fatal("expected no square root of the primitive root")
+	}
+	if !SqrtModFr(&root, &ZERO) || !EqualZero(&root) {
+		t.Fatal("expected square root of zero to be zero")
+	}
+	var sq, got Fr
+	for i := 0; i < 20; i++ {
+		x := RandomFr()
+		SqrModFr(&sq, x)
+		if LegendreFr(&sq) != 1 {
+			t.Fatal("expected a square")
+		}
+		if !SqrtModFr(&root, &sq) {
+			t.Fatal("expected a square root")
+		}
+		SqrModFr(&got, &root)
+		if !EqualFr(&got, &sq) {
+			t.Fatalf("sqrt(%s)^2 = %s", FrStr(&sq), FrStr(&got))
+		}
+	}
+	// -1 is a square, with the 4th roots of unity as square roots
+	if !SqrtModFr(&root, &MODULUS_MINUS1) {
+		t.Fatal("expected -1 to be a square")
+	}
+	SqrModFr(&got, &root)
+	if !EqualFr(&got, &MODULUS_MINUS1) {
+		t.Fatalf("sqrt(-1)^2 = %s", FrStr(&got))
+	}
+}
@@ -0,0 +1,311 @@
+// +build bignum_gnark
+
+package ff
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var ZERO_G1 G1Point
+
+var GenG1 G1Point
+var GenG2 G2Point
+
+var ZeroG1 G1Point
+var ZeroG2 G2Point
+
+func initG1G2() {
+	g1Gen, g2Gen, _, _ := bls12381.Generators()
+	GenG1 = G1Point(g1Gen)
+	GenG2 = G2Point(g2Gen)
+	ClearG1(&ZeroG1)
+	ClearG2(&ZeroG2)
+}
+
+// G1Point is a gnark-crypto G1 point in jacobian coordinates.
+type G1Point bls12381.G1Jac
+
+// ClearG1 sets the point to infinity, (1, 1, 0) in jacobian coordinates.
+func ClearG1(x *G1Point) {
+	x.X.SetOne()
+	x.Y.SetOne()
+	x.Z.SetZero()
+}
+
+func CopyG1(dst *G1Point, v *G1Point) {
+	*dst = *v
+}
+
+func MulG1(dst *G1Point, a *G1Point, b *Fr) {
+	(*bls12381.G1Jac)(dst).ScalarMultiplication((*bls12381.G1Jac)(a), frToBig(b))
+}
+
+func AddG1(dst *G1Point, a *G1Point, b *G1Point) {
+	var out bls12381.G1Jac
+	out.Set((*bls12381.G1Jac)(a))
+	out.AddAssign((*bls12381.G1Jac)(b))
+	*dst = G1Point(out)
+}
+
+func SubG1(dst *G1Point, a *G1Point, b *G1Point) {
+	var out bls12381.G1Jac
+	out.Set((*bls12381.G1Jac)(a))
+	out.SubAssign((*bls12381.G1Jac)(b))
+	*dst = G1Point(out)
+}
+
+// StrG1 formats the point like mcl does: "0" for the point at infinity, "1 <x> <y>" otherwise, affine and decimal.
+func StrG1(v *G1Point) string {
+	var p bls12381.G1Affine
+	p.FromJacobian((*bls12381.G1Jac)(v))
+	if p.IsInfinity() {
+		return "0"
+	}
+	return "1 " + p.X.String() + " " + p.Y.String()
+}
+
+func NegG1(dst *G1Point) {
+	(*bls12381.G1Jac)(dst).Neg((*bls12381.G1Jac)(dst))
+}
+
+// G2Point is a gnark-crypto G2 point in jacobian coordinates.
+type G2Point bls12381.G2Jac
+
+// ClearG2 sets the point to infinity, (1, 1, 0) in jacobian coordinates.
+func ClearG2(x *G2Point) {
+	x.X.SetOne()
+	x.Y.SetOne()
+	x.Z.SetZero()
+}
+
+func CopyG2(dst *G2Point, v *G2Point) {
+	*dst = *v
+}
+
+func MulG2(dst *G2Point, a *G2Point, b *Fr) {
+	(*bls12381.G2Jac)(dst).ScalarMultiplication((*bls12381.G2Jac)(a), frToBig(b))
+}
+
+func AddG2(dst *G2Point, a *G2Point, b *G2Point) {
+	var out bls12381.G2Jac
+	out.Set((*bls12381.G2Jac)(a))
+	out.AddAssign((*bls12381.G2Jac)(b))
+	*dst = G2Point(out)
+}
+
+func SubG2(dst *G2Point, a *G2Point, b *G2Point) {
+	var out bls12381.G2Jac
+	out.Set((*bls12381.G2Jac)(a))
+	out.SubAssign((*bls12381.G2Jac)(b))
+	*dst = G2Point(out)
+}
+
+func NegG2(dst *G2Point) {
+	(*bls12381.G2Jac)(dst).Neg((*bls12381.G2Jac)(dst))
+}
+
+// StrG2 formats the point like mcl does: "0" for the point at infinity,
+// "1 <x.a> <x.b> <y.a> <y.b>" otherwise, affine and decimal.
+func StrG2(v *G2Point) string {
+	var p bls12381.G2Affine
+	p.FromJacobian((*bls12381.G2Jac)(v))
+	if p.IsInfinity() {
+		return "0"
+	}
+	return "1 " + p.X.A0.String() + " " + p.X.A1.String() + " " + p.Y.A0.String() + " " + p.Y.A1.String()
+}
+
+func EqualG1(a *G1Point, b *G1Point) bool {
+	return (*bls12381.G1Jac)(a).Equal((*bls12381.G1Jac)(b))
+}
+
+func EqualG2(a *G2Point, b *G2Point) bool {
+	return (*bls12381.G2Jac)(a).Equal((*bls12381.G2Jac)(b))
+}
+
+func toAffineG1s(points []G1Point) []bls12381.G1Affine {
+	out := make([]bls12381.G1Affine, len(points), len(points))
+	for i := 0; i < len(points); i++ {
+		out[i].FromJacobian((*bls12381.G1Jac)(&points[i]))
+	}
+	return out
+}
+
+func toAffineG2s(points []G2Point) []bls12381.G2Affine {
+	out := make([]bls12381.G2Affine, len(points), len(points))
+	for i := 0; i < len(points); i++ {
+		out[i].FromJacobian((*bls12381.G2Jac)(&points[i]))
+	}
+	return out
+}
+
+func LinCombG1(numbers []G1Point, factors []Fr) *G1Point {
+	var out G1Point
+	// Fr is an alias of fr.Element, so the slice of factors can be converted directly.
+	frs := *(*[]fr.Element)(unsafe.Pointer(&factors))
+	if _, err := (*bls12381.G1Jac)(&out).MultiExp(toAffineG1s(numbers), frs, ecc.MultiExpConfig{}); err != nil {
+		panic(err)
+	}
+	return &out
+}
+
+func LinCombG2(numbers []G2Point, factors []Fr) *G2Point {
+	var out G2Point
+	// Fr is an alias of fr.Element, so the slice of factors can be converted directly.
+	frs := *(*[]fr.Element)(unsafe.Pointer(&factors))
+	if _, err := (*bls12381.G2Jac)(&out).MultiExp(toAffineG2s(numbers), frs, ecc.MultiExpConfig{}); err != nil {
+		panic(err)
+	}
+	return &out
+}
+
+// e(a1^(-1), a2) * e(b1,  b2) = 1_T
+func PairingsVerify(a1 *G1Point, a2 *G2Point, b1 *G1Point, b2 *G2Point) bool {
+	var negA1 G1Point
+	CopyG1(&negA1, a1)
+	NegG1(&negA1)
+	return PairingProductIsOne([]G1Point{negA1, *b1}, []G2Point{*a2, *b2})
+}
+
+// PairingProductIsOne checks e(g1s[0], g2s[0]) * e(g1s[1], g2s[1]) * ... = 1_T,
+// with a single multi-Miller loop and final exponentiation. An empty product is one.
+func PairingProductIsOne(g1s []G1Point, g2s []G2Point) bool {
+	if len(g1s) != len(g2s) {
+		panic("got PairingProductIsOne g1s/g2s length mismatch")
+	}
+	if len(g1s) == 0 {
+		return true
+	}
+	ok, err := bls12381.PairingCheck(toAffineG1s(g1s), toAffineG2s(g2s))
+	if err != nil {
+		panic(err)
+	}
+	return ok
+}
+
+func DebugG1s(msg string, values []G1Point) {
+	var out strings.Builder
+	for i := range values {
+		out.WriteString(fmt.Sprintf("%s %d: %s\n", msg, i, StrG1(&values[i])))
+	}
+	fmt.Println(out.String())
+}
+
+// gnark-crypto uses the ZCash format, see point_encoding.go.
+func g1ToUncompressed(p *G1Point) [G1UncompressedSize]byte {
+	var aff bls12381.G1Affine
+	aff.FromJacobian((*bls12381.G1Jac)(p))
+	return aff.RawBytes()
+}
+
+// Coordinates are canonical and on the curve at this point, so they are set directly, without the subgroup check
+// that G1Affine.SetBytes does.
+func g1FromUncompressedUnchecked(dst *G1Point, raw *[G1UncompressedSize]byte) error {
+	var aff bls12381.G1Affine
+	aff.X.SetBytes(raw[0:48])
+	aff.Y.SetBytes(raw[48:96])
+	if !aff.IsOnCurve() {
+		return ErrPointNotOnCurve
+	}
+	(*bls12381.G1Jac)(dst).FromAffine(&aff)
+	return nil
+}
+
+func g1InSubgroup(p *G1Point) bool {
+	return (*bls12381.G1Jac)(p).IsInSubGroup()
+}
+
+func g2ToUncompressed(p *G2Point) [G2UncompressedSize]byte {
+	var aff bls12381.G2Affine
+	aff.FromJacobian((*bls12381.G2Jac)(p))
+	return aff.RawBytes()
+}
+
+func g2FromUncompressedUnchecked(dst *G2Point, raw *[G2UncompressedSize]byte) error {
+	var aff bls12381.G2Affine
+	aff.X.A1.SetBytes(raw[0:48])
+	aff.X.A0.SetBytes(raw[48:96])
+	aff.Y.A1.SetBytes(raw[96:144])
+	aff.Y.A0.SetBytes(raw[144:192])
+	if !aff.IsOnCurve() {
+		return ErrPointNotOnCurve
+	}
+	(*bls12381.G2Jac)(dst).FromAffine(&aff)
+	return nil
+}
+
+func g2InSubgroup(p *G2Point) bool {
+	return (*bls12381.G2Jac)(p).IsInSubGroup()
+}
+
+// GTElement is an element of the target group of the pairing, a subgroup of Fp12.
+type GTElement bls12381.GT
+
+func Pairing(dst *GTElement, a *G1Point, b *G2Point) {
+	out, err := bls12381.Pair(toAffineG1s([]G1Point{*a}), toAffineG2s([]G2Point{*b}))
+	if err != nil {
+		panic(err)
+	}
+	*dst = GTElement(out)
+}
+
+func MulGT(dst *GTElement, a *GTElement, b *GTElement) {
+	(*bls12381.GT)(dst).Mul((*bls12381.GT)(a), (*bls12381.GT)(b))
+}
+
+func ExpGT(dst *GTElement, a *GTElement, e *Fr) {
+	(*bls12381.GT)(dst).Exp(*(*bls12381.GT)(a), frToBig(e))
+}
+
+func EqualGT(a *GTElement, b *GTElement) bool {
+	return (*bls12381.GT)(a).Equal((*bls12381.GT)(b))
+}
+
+func IsOneGT(v *GTElement) bool {
+	return (*bls12381.GT)(v).IsOne()
+}
+
+// GTToBytes encodes the GT element, see GTSize for the format.
+func GTToBytes(v *GTElement) [GTSize]byte {
+	return (*bls12381.GT)(v).Bytes()
+}
+
+// GTFromBytes decodes a GT element, see GTSize for the format, and checks it is in the order r subgroup.
+func GTFromBytes(dst *GTElement, b []byte) error {
+	if len(b) != GTSize {
+		return fmt.Errorf("expected %d bytes, got %d", GTSize, len(b))
+	}
+	var out bls12381.GT
+	if err := out.SetBytes(b); err != nil {
+		return fmt.Errorf("%w: %v", ErrNonCanonicalCoordinate, err)
+	}
+	if !out.IsInSubGroup() {
+		return ErrGTNotInSubgroup
+	}
+	*dst = GTElement(out)
+	return nil
+}
+
+func hashToG1(out *G1Point, msg, dst []byte) error {
+	p, err := bls12381.HashToG1(msg, dst)
+	if err != nil {
+		return err
+	}
+	(*bls12381.G1Jac)(out).FromAffine(&p)
+	return nil
+}
+
+func hashToG2(out *G2Point, msg, dst []byte) error {
+	p, err := bls12381.HashToG2(msg, dst)
+	if err != nil {
+		return err
+	}
+	(*bls12381.G2Jac)(out).FromAffine(&p)
+	return nil
+}
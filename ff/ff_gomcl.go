@@ -1,9 +1,10 @@
-// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls
+// +build !bignum_pure,!bignum_hol256,!bignum_kilic,!bignum_hbls,!bignum_gnark
 
 package ff
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 	"unsafe"
 
@@ -20,6 +21,19 @@ var ZeroG2 G2Point
 
 // Herumi BLS doesn't offer these points to us, so we have to work around it by declaring them ourselves.
 func initG1G2() {
+	switch currentCurve {
+	case BLS12_381:
+		initG1G2BLS12381()
+	case BN254:
+		initG1G2BN254()
+	}
+
+	// Clear sets all coordinates to zero: other z = 0 representations of infinity do not pair to one in mcl
+	ClearG1(&ZeroG1)
+	ClearG2(&ZeroG2)
+}
+
+func initG1G2BLS12381() {
 	GenG1.X.SetString("3685416753713387016781088315183077757961620795782546409894578378688607592378376318836054947676345821548104185464507", 10)
 	GenG1.Y.SetString("1339506544944476473020471379941921221584933875938349620426543736416511423956333506472724655353366534992391756441569", 10)
 	GenG1.Z.SetInt64(1)
@@ -30,17 +44,20 @@ func initG1G2() {
 	GenG2.Y.D[1].SetString("927553665492332455747201965776037880757740193453592970025027978793976877002675564980949289727957565575433344219582", 10)
 	GenG2.Z.D[0].SetInt64(1)
 	GenG2.Z.D[1].Clear()
+}
 
-	ZeroG1.X.SetInt64(1)
-	ZeroG1.Y.SetInt64(1)
-	ZeroG1.Z.SetInt64(0)
+// The generators used by the Ethereum precompiles, see EIP-197.
+func initG1G2BN254() {
+	GenG1.X.SetInt64(1)
+	GenG1.Y.SetInt64(2)
+	GenG1.Z.SetInt64(1)
 
-	ZeroG2.X.D[0].SetInt64(1)
-	ZeroG2.X.D[1].SetInt64(0)
-	ZeroG2.Y.D[0].SetInt64(1)
-	ZeroG2.Y.D[1].SetInt64(0)
-	ZeroG2.Z.D[0].SetInt64(0)
-	ZeroG2.Z.D[1].SetInt64(0)
+	GenG2.X.D[0].SetString("10857046999023057135944570762232829481370756359578518086990519993285655852781", 10)
+	GenG2.X.D[1].SetString("11559732032986387107991004021392285783925812861821192530917403151452391805634", 10)
+	GenG2.Y.D[0].SetString("8495653923123431417604973247489272438418190587263600148770280649306958101930", 10)
+	GenG2.Y.D[1].SetString("4082367875863433681332203403145435568316851327593401208105741076214120093531", 10)
+	GenG2.Z.D[0].SetInt64(1)
+	GenG2.Z.D[1].Clear()
 }
 
 // TODO types file, swap BLS with build args
@@ -116,6 +133,11 @@ func EqualG2(a *G2Point, b *G2Point) bool {
 
 func LinCombG1(numbers []G1Point, factors []Fr) *G1Point {
 	var out G1Point
+	// G1MulVec indexes the first element, also for empty inputs
+	if len(numbers) == 0 {
+		ClearG1(&out)
+		return &out
+	}
 	// We're just using unsafe to cast elements that are an alias anyway, no problem.
 	// Go doesn't let us do the cast otherwise without copy.
 	gmcl.G1MulVec((*gmcl.G1)(&out), *(*[]gmcl.G1)(unsafe.Pointer(&numbers)), *(*[]gmcl.Fr)(unsafe.Pointer(&factors)))
@@ -124,6 +146,11 @@ func LinCombG1(numbers []G1Point, factors []Fr) *G1Point {
 
 func LinCombG2(numbers []G2Point, factors []Fr) *G2Point {
 	var out G2Point
+	// G2MulVec indexes the first element, also for empty inputs
+	if len(numbers) == 0 {
+		ClearG2(&out)
+		return &out
+	}
 	// We're just using unsafe to cast elements that are an alias anyway, no problem.
 	// Go doesn't let us do the cast otherwise without copy.
 	gmcl.G2MulVec((*gmcl.G2)(&out), *(*[]gmcl.G2)(unsafe.Pointer(&numbers)), *(*[]gmcl.Fr)(unsafe.Pointer(&factors)))
@@ -132,30 +159,26 @@ func LinCombG2(numbers []G2Point, factors []Fr) *G2Point {
 
 // e(a1^(-1), a2) * e(b1,  b2) = 1_T
 func PairingsVerify(a1 *G1Point, a2 *G2Point, b1 *G1Point, b2 *G2Point) bool {
-	var tmp gmcl.GT
-	gmcl.Pairing(&tmp, (*gmcl.G1)(a1), (*gmcl.G2)(a2))
-	//fmt.Println("tmp", tmp.GetString(10))
-	var tmp2 gmcl.GT
-	gmcl.Pairing(&tmp2, (*gmcl.G1)(b1), (*gmcl.G2)(b2))
-
-	// invert left pairing
-	var tmp3 gmcl.GT
-	gmcl.GTInv(&tmp3, &tmp)
-
-	// multiply the two
-	var tmp4 gmcl.GT
-	gmcl.GTMul(&tmp4, &tmp3, &tmp2)
-
-	// final exp.
-	var tmp5 gmcl.GT
-	gmcl.FinalExp(&tmp5, &tmp4)
-
-	// = 1_T
-	return tmp5.IsOne()
+	var negA1 G1Point
+	CopyG1(&negA1, a1)
+	NegG1(&negA1)
+	return PairingProductIsOne([]G1Point{negA1, *b1}, []G2Point{*a2, *b2})
+}
 
-	// TODO, alternatively use the equal check (faster or slower?):
-	////fmt.Println("tmp2", tmp2.GetString(10))
-	//return tmp.IsEqual(&tmp2)
+// PairingProductIsOne checks e(g1s[0], g2s[0]) * e(g1s[1], g2s[1]) * ... = 1_T,
+// with a single multi-Miller loop and final exponentiation. An empty product is one.
+func PairingProductIsOne(g1s []G1Point, g2s []G2Point) bool {
+	if len(g1s) != len(g2s) {
+		panic("got PairingProductIsOne g1s/g2s length mismatch")
+	}
+	if len(g1s) == 0 {
+		return true
+	}
+	var ml gmcl.GT
+	gmcl.MillerLoopVec(&ml, *(*[]gmcl.G1)(unsafe.Pointer(&g1s)), *(*[]gmcl.G2)(unsafe.Pointer(&g2s)))
+	var out gmcl.GT
+	gmcl.FinalExp(&out, &ml)
+	return out.IsOne()
 }
 
 func DebugG1s(msg string, values []G1Point) {
@@ -165,3 +188,173 @@ func DebugG1s(msg string, values []G1Point) {
 	}
 	fmt.Println(out.String())
 }
+
+// The encodings are built from the affine coordinates, rather than with SerializeUncompressed, whose format
+// depends on the process-wide ETH serialization mode of mcl, which other users of mcl may change.
+// The format is the ZCash one, see point_encoding.go.
+
+func mclFpToBig(x *gmcl.Fp) *big.Int {
+	v, ok := new(big.Int).SetString(strings.TrimPrefix(x.GetString(16), "0x"), 16)
+	if !ok {
+		panic(fmt.Sprintf("unexpected Fp hex string %q", x.GetString(16)))
+	}
+	return v
+}
+
+func mclFpFromBig(dst *gmcl.Fp, v *big.Int) {
+	if err := dst.SetString(v.Text(16), 16); err != nil {
+		panic(err)
+	}
+}
+
+func g1ToUncompressed(p *G1Point) (out [G1UncompressedSize]byte) {
+	if (*gmcl.G1)(p).IsZero() {
+		out[0] = infinityFlag
+		return
+	}
+	var a gmcl.G1
+	gmcl.G1Normalize(&a, (*gmcl.G1)(p))
+	mclFpToBig(&a.X).FillBytes(out[:48])
+	mclFpToBig(&a.Y).FillBytes(out[48:])
+	return
+}
+
+// The order is not verified when decoding, that is left to g1InSubgroup. This does not depend on the
+// process-wide VerifyOrderG1 setting of mcl.
+func g1FromUncompressedUnchecked(dst *G1Point, raw *[G1UncompressedSize]byte) error {
+	x, err := fpFromBytes(raw[:48])
+	if err != nil {
+		return err
+	}
+	y, err := fpFromBytes(raw[48:])
+	if err != nil {
+		return err
+	}
+	// y^2 = x^3 + 4
+	if fpMul(y, y).Cmp(fpAdd(fpMul(fpMul(x, x), x), big.NewInt(4))) != 0 {
+		return ErrPointNotOnCurve
+	}
+	var a gmcl.G1
+	mclFpFromBig(&a.X, x)
+	mclFpFromBig(&a.Y, y)
+	a.Z.SetInt64(1)
+	*dst = G1Point(a)
+	return nil
+}
+
+func g1InSubgroup(p *G1Point) bool {
+	return (*gmcl.G1)(p).IsValidOrder()
+}
+
+func g2ToUncompressed(p *G2Point) (out [G2UncompressedSize]byte) {
+	if (*gmcl.G2)(p).IsZero() {
+		out[0] = infinityFlag
+		return
+	}
+	var a gmcl.G2
+	gmcl.G2Normalize(&a, (*gmcl.G2)(p))
+	fp2{c0: mclFpToBig(&a.X.D[0]), c1: mclFpToBig(&a.X.D[1])}.fillBytes(out[:96])
+	fp2{c0: mclFpToBig(&a.Y.D[0]), c1: mclFpToBig(&a.Y.D[1])}.fillBytes(out[96:])
+	return
+}
+
+func g2FromUncompressedUnchecked(dst *G2Point, raw *[G2UncompressedSize]byte) error {
+	x, err := fp2FromBytes(raw[:96])
+	if err != nil {
+		return err
+	}
+	y, err := fp2FromBytes(raw[96:])
+	if err != nil {
+		return err
+	}
+	if !y.square().equal(x.square().mul(x).add(g2B)) {
+		return ErrPointNotOnCurve
+	}
+	var a gmcl.G2
+	mclFpFromBig(&a.X.D[0], x.c0)
+	mclFpFromBig(&a.X.D[1], x.c1)
+	mclFpFromBig(&a.Y.D[0], y.c0)
+	mclFpFromBig(&a.Y.D[1], y.c1)
+	a.Z.D[0].SetInt64(1)
+	*dst = G2Point(a)
+	return nil
+}
+
+func g2InSubgroup(p *G2Point) bool {
+	return (*gmcl.G2)(p).IsValidOrder()
+}
+
+// GTElement is an element of the target group of the pairing, a subgroup of Fp12.
+type GTElement gmcl.GT
+
+func Pairing(dst *GTElement, a *G1Point, b *G2Point) {
+	gmcl.Pairing((*gmcl.GT)(dst), (*gmcl.G1)(a), (*gmcl.G2)(b))
+}
+
+func MulGT(dst *GTElement, a *GTElement, b *GTElement) {
+	gmcl.GTMul((*gmcl.GT)(dst), (*gmcl.GT)(a), (*gmcl.GT)(b))
+}
+
+func ExpGT(dst *GTElement, a *GTElement, e *Fr) {
+	gmcl.GTPow((*gmcl.GT)(dst), (*gmcl.GT)(a), (*gmcl.Fr)(e))
+}
+
+func EqualGT(a *GTElement, b *GTElement) bool {
+	return (*gmcl.GT)(a).IsEqual((*gmcl.GT)(b))
+}
+
+func IsOneGT(v *GTElement) bool {
+	return (*gmcl.GT)(v).IsOne()
+}
+
+// GTToBytes encodes the GT element, see GTSize for the format.
+func GTToBytes(v *GTElement) (out [GTSize]byte) {
+	checkEncodingCurve()
+	// mcl lists the coefficients from the lowest to the highest, the encoding starts with the highest
+	parts := strings.Fields((*gmcl.GT)(v).GetString(10))
+	for i, p := range parts {
+		n, _ := new(big.Int).SetString(p, 10)
+		n.FillBytes(out[(11-i)*48 : (12-i)*48])
+	}
+	return
+}
+
+// GTFromBytes decodes a GT element, see GTSize for the format, and checks it is in the order r subgroup.
+func GTFromBytes(dst *GTElement, b []byte) error {
+	if currentCurve != BLS12_381 {
+		return ErrUnsupportedCurve
+	}
+	if len(b) != GTSize {
+		return fmt.Errorf("expected %d bytes, got %d", GTSize, len(b))
+	}
+	parts := make([]string, 12, 12)
+	for i := range parts {
+		n := new(big.Int).SetBytes(b[(11-i)*48 : (12-i)*48])
+		if n.Cmp(fpModulus) >= 0 {
+			return ErrNonCanonicalCoordinate
+		}
+		parts[i] = n.String()
+	}
+	var out gmcl.GT
+	if err := out.SetString(strings.Join(parts, " "), 10); err != nil {
+		return err
+	}
+	// v^r = 1, computed as v^(r-1) * v, as exponents are reduced modulo r
+	var tmp gmcl.GT
+	gmcl.GTPow(&tmp, &out, (*gmcl.Fr)(&MODULUS_MINUS1))
+	gmcl.GTMul(&tmp, &tmp, &out)
+	if !tmp.IsOne() {
+		return ErrGTNotInSubgroup
+	}
+	*dst = GTElement(out)
+	return nil
+}
+
+// go-mcl does not expose the domain separation tag setting of mcl, so use the math/big implementation.
+func hashToG1(out *G1Point, msg, dst []byte) error {
+	return sswuHashToG1(out, msg, dst)
+}
+
+func hashToG2(out *G2Point, msg, dst []byte) error {
+	return sswuHashToG2(out, msg, dst)
+}
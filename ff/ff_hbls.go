@@ -0,0 +1,267 @@
+// +build bignum_hbls
+
+package ff
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unsafe"
+
+	hbls "github.com/herumi/bls-eth-go-binary/bls"
+)
+
+var ZERO_G1 G1Point
+
+var GenG1 G1Point
+var GenG2 G2Point
+
+var ZeroG1 G1Point
+var ZeroG2 G2Point
+
+// Herumi BLS doesn't offer these points to us, so we have to work around it by declaring them ourselves.
+func initG1G2() {
+	GenG1.X.SetString("3685416753713387016781088315183077757961620795782546409894578378688607592378376318836054947676345821548104185464507", 10)
+	GenG1.Y.SetString("1339506544944476473020471379941921221584933875938349620426543736416511423956333506472724655353366534992391756441569", 10)
+	GenG1.Z.SetInt64(1)
+
+	GenG2.X.D[0].SetString("352701069587466618187139116011060144890029952792775240219908644239793785735715026873347600343865175952761926303160", 10)
+	GenG2.X.D[1].SetString("3059144344244213709971259814753781636986470325476647558659373206291635324768958432433509563104347017837885763365758", 10)
+	GenG2.Y.D[0].SetString("1985150602287291935568054521177171638300868978215655730859378665066344726373823718423869104263333984641494340347905", 10)
+	GenG2.Y.D[1].SetString("927553665492332455747201965776037880757740193453592970025027978793976877002675564980949289727957565575433344219582", 10)
+	GenG2.Z.D[0].SetInt64(1)
+	GenG2.Z.D[1].Clear()
+
+	// Clear sets all coordinates to zero: other z = 0 representations of infinity do not pair to one in mcl
+	ClearG1(&ZeroG1)
+	ClearG2(&ZeroG2)
+}
+
+// TODO types file, swap BLS with build args
+type G1Point hbls.G1
+
+func ClearG1(x *G1Point) {
+	(*hbls.G1)(x).Clear()
+}
+
+func CopyG1(dst *G1Point, v *G1Point) {
+	*dst = *v
+}
+
+func MulG1(dst *G1Point, a *G1Point, b *Fr) {
+	hbls.G1Mul((*hbls.G1)(dst), (*hbls.G1)(a), (*hbls.Fr)(b))
+}
+
+func AddG1(dst *G1Point, a *G1Point, b *G1Point) {
+	hbls.G1Add((*hbls.G1)(dst), (*hbls.G1)(a), (*hbls.G1)(b))
+}
+
+func SubG1(dst *G1Point, a *G1Point, b *G1Point) {
+	hbls.G1Sub((*hbls.G1)(dst), (*hbls.G1)(a), (*hbls.G1)(b))
+}
+
+func StrG1(v *G1Point) string {
+	return (*hbls.G1)(v).GetString(10)
+}
+
+func NegG1(dst *G1Point) {
+	// in-place should be safe here (TODO double check)
+	hbls.G1Neg((*hbls.G1)(dst), (*hbls.G1)(dst))
+}
+
+type G2Point hbls.G2
+
+func ClearG2(x *G2Point) {
+	(*hbls.G2)(x).Clear()
+}
+
+func CopyG2(dst *G2Point, v *G2Point) {
+	*dst = *v
+}
+
+func MulG2(dst *G2Point, a *G2Point, b *Fr) {
+	hbls.G2Mul((*hbls.G2)(dst), (*hbls.G2)(a), (*hbls.Fr)(b))
+}
+
+func AddG2(dst *G2Point, a *G2Point, b *G2Point) {
+	hbls.G2Add((*hbls.G2)(dst), (*hbls.G2)(a), (*hbls.G2)(b))
+}
+
+func SubG2(dst *G2Point, a *G2Point, b *G2Point) {
+	hbls.G2Sub((*hbls.G2)(dst), (*hbls.G2)(a), (*hbls.G2)(b))
+}
+
+func NegG2(dst *G2Point) {
+	// in-place should be safe here (TODO double check)
+	hbls.G2Neg((*hbls.G2)(dst), (*hbls.G2)(dst))
+}
+
+func StrG2(v *G2Point) string {
+	return (*hbls.G2)(v).GetString(10)
+}
+
+func EqualG1(a *G1Point, b *G1Point) bool {
+	return (*hbls.G1)(a).IsEqual((*hbls.G1)(b))
+}
+
+func EqualG2(a *G2Point, b *G2Point) bool {
+	return (*hbls.G2)(a).IsEqual((*hbls.G2)(b))
+}
+
+func LinCombG1(numbers []G1Point, factors []Fr) *G1Point {
+	var out G1Point
+	// We're just using unsafe to cast elements that are an alias anyway, no problem.
+	// Go doesn't let us do the cast otherwise without copy.
+	hbls.G1MulVec((*hbls.G1)(&out), *(*[]hbls.G1)(unsafe.Pointer(&numbers)), *(*[]hbls.Fr)(unsafe.Pointer(&factors)))
+	return &out
+}
+
+func LinCombG2(numbers []G2Point, factors []Fr) *G2Point {
+	var out G2Point
+	// We're just using unsafe to cast elements that are an alias anyway, no problem.
+	// Go doesn't let us do the cast otherwise without copy.
+	hbls.G2MulVec((*hbls.G2)(&out), *(*[]hbls.G2)(unsafe.Pointer(&numbers)), *(*[]hbls.Fr)(unsafe.Pointer(&factors)))
+	return &out
+}
+
+// e(a1^(-1), a2) * e(b1,  b2) = 1_T
+func PairingsVerify(a1 *G1Point, a2 *G2Point, b1 *G1Point, b2 *G2Point) bool {
+	var negA1 G1Point
+	CopyG1(&negA1, a1)
+	NegG1(&negA1)
+	return PairingProductIsOne([]G1Point{negA1, *b1}, []G2Point{*a2, *b2})
+}
+
+// PairingProductIsOne checks e(g1s[0], g2s[0]) * e(g1s[1], g2s[1]) * ... = 1_T,
+// with a single multi-Miller loop and final exponentiation. An empty product is one.
+func PairingProductIsOne(g1s []G1Point, g2s []G2Point) bool {
+	if len(g1s) != len(g2s) {
+		panic("got PairingProductIsOne g1s/g2s length mismatch")
+	}
+	if len(g1s) == 0 {
+		return true
+	}
+	var ml hbls.GT
+	hbls.MillerLoopVec(&ml, *(*[]hbls.G1)(unsafe.Pointer(&g1s)), *(*[]hbls.G2)(unsafe.Pointer(&g2s)))
+	var out hbls.GT
+	hbls.FinalExp(&out, &ml)
+	return out.IsOne()
+}
+
+func DebugG1s(msg string, values []G1Point) {
+	var out strings.Builder
+	for i := range values {
+		out.WriteString(fmt.Sprintf("%s %d: %s\n", msg, i, StrG1(&values[i])))
+	}
+	fmt.Println(out.String())
+}
+
+// In ETH serialization mode, mcl uses the ZCash format, see point_encoding.go.
+func g1ToUncompressed(p *G1Point) (out [G1UncompressedSize]byte) {
+	copy(out[:], (*hbls.G1)(p).SerializeUncompressed())
+	return
+}
+
+// The order is not verified when deserializing, that is left to g1InSubgroup.
+func g1FromUncompressedUnchecked(dst *G1Point, raw *[G1UncompressedSize]byte) error {
+	if err := (*hbls.G1)(dst).DeserializeUncompressed(raw[:]); err != nil {
+		return fmt.Errorf("%w: %v", ErrPointNotOnCurve, err)
+	}
+	return nil
+}
+
+func g1InSubgroup(p *G1Point) bool {
+	return (*hbls.G1)(p).IsValidOrder()
+}
+
+func g2ToUncompressed(p *G2Point) (out [G2UncompressedSize]byte) {
+	copy(out[:], (*hbls.G2)(p).SerializeUncompressed())
+	return
+}
+
+func g2FromUncompressedUnchecked(dst *G2Point, raw *[G2UncompressedSize]byte) error {
+	if err := (*hbls.G2)(dst).DeserializeUncompressed(raw[:]); err != nil {
+		return fmt.Errorf("%w: %v", ErrPointNotOnCurve, err)
+	}
+	return nil
+}
+
+func g2InSubgroup(p *G2Point) bool {
+	return (*hbls.G2)(p).IsValidOrder()
+}
+
+// GTElement is an element of the target group of the pairing, a subgroup of Fp12.
+type GTElement hbls.GT
+
+func Pairing(dst *GTElement, a *G1Point, b *G2Point) {
+	hbls.Pairing((*hbls.GT)(dst), (*hbls.G1)(a), (*hbls.G2)(b))
+}
+
+func MulGT(dst *GTElement, a *GTElement, b *GTElement) {
+	hbls.GTMul((*hbls.GT)(dst), (*hbls.GT)(a), (*hbls.GT)(b))
+}
+
+func ExpGT(dst *GTElement, a *GTElement, e *Fr) {
+	hbls.GTPow((*hbls.GT)(dst), (*hbls.GT)(a), (*hbls.Fr)(e))
+}
+
+func EqualGT(a *GTElement, b *GTElement) bool {
+	return (*hbls.GT)(a).IsEqual((*hbls.GT)(b))
+}
+
+func IsOneGT(v *GTElement) bool {
+	return (*hbls.GT)(v).IsOne()
+}
+
+// GTToBytes encodes the GT element, see GTSize for the format.
+func GTToBytes(v *GTElement) (out [GTSize]byte) {
+	checkEncodingCurve()
+	// mcl lists the coefficients from the lowest to the highest, the encoding starts with the highest
+	parts := strings.Fields((*hbls.GT)(v).GetString(10))
+	for i, p := range parts {
+		n, _ := new(big.Int).SetString(p, 10)
+		n.FillBytes(out[(11-i)*48 : (12-i)*48])
+	}
+	return
+}
+
+// GTFromBytes decodes a GT element, see GTSize for the format, and checks it is in the order r subgroup.
+func GTFromBytes(dst *GTElement, b []byte) error {
+	if currentCurve != BLS12_381 {
+		return ErrUnsupportedCurve
+	}
+	if len(b) != GTSize {
+		return fmt.Errorf("expected %d bytes, got %d", GTSize, len(b))
+	}
+	parts := make([]string, 12, 12)
+	for i := range parts {
+		n := new(big.Int).SetBytes(b[(11-i)*48 : (12-i)*48])
+		if n.Cmp(fpModulus) >= 0 {
+			return ErrNonCanonicalCoordinate
+		}
+		parts[i] = n.String()
+	}
+	var out hbls.GT
+	if err := out.SetString(strings.Join(parts, " "), 10); err != nil {
+		return err
+	}
+	// v^r = 1, computed as v^(r-1) * v, as exponents are reduced modulo r
+	var tmp hbls.GT
+	hbls.GTPow(&tmp, &out, (*hbls.Fr)(&MODULUS_MINUS1))
+	hbls.GTMul(&tmp, &tmp, &out)
+	if !tmp.IsOne() {
+		return ErrGTNotInSubgroup
+	}
+	*dst = GTElement(out)
+	return nil
+}
+
+// herumi takes the map-to-curve mode and the domain separation tag as process-global settings,
+// shared with the signature functions of applications linking it, so use the math/big implementation.
+func hashToG1(out *G1Point, msg, dst []byte) error {
+	return sswuHashToG1(out, msg, dst)
+}
+
+func hashToG2(out *G2Point, msg, dst []byte) error {
+	return sswuHashToG2(out, msg, dst)
+}
@@ -0,0 +1,331 @@
+// +build bignum_pure bignum_kilic
+
+// G1, G2 and GT operations on github.com/kilic/bls12-381, a pure-Go library,
+// shared by the bignum_pure and bignum_kilic backends. Only Fr differs between the two, see bignum_pure.go and bignum_kilic.go.
+
+package ff
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	kbls "github.com/kilic/bls12-381"
+)
+
+var ZERO_G1 G1Point
+
+var GenG1 G1Point
+var GenG2 G2Point
+
+var ZeroG1 G1Point
+var ZeroG2 G2Point
+
+func initG1G2() {
+	GenG1 = G1Point(kbls.G1One)
+	GenG2 = G2Point(kbls.G2One)
+	ZeroG1 = G1Point(*kbls.NewG1().Zero())
+	ZeroG2 = G2Point(*kbls.NewG2().Zero())
+}
+
+// The kilic library only implements BLS12-381. The pure backend can switch the scalar field to other curves,
+// but scalar multiplications and pairings would then be meaningless, so refuse those.
+func checkKilicCurve() {
+	if currentCurve != BLS12_381 {
+		panic(fmt.Sprintf("kilic G1/G2 operations only support %s, current curve is %s", BLS12_381, currentCurve))
+	}
+}
+
+type G1Point kbls.PointG1
+
+func ClearG1(x *G1Point) {
+	(*kbls.PointG1)(x).Zero()
+}
+
+func CopyG1(dst *G1Point, v *G1Point) {
+	*dst = *v
+}
+
+func MulG1(dst *G1Point, a *G1Point, b *Fr) {
+	checkKilicCurve()
+	kbls.NewG1().MulScalarBig((*kbls.PointG1)(dst), (*kbls.PointG1)(a), frToBig(b))
+}
+
+func AddG1(dst *G1Point, a *G1Point, b *G1Point) {
+	kbls.NewG1().Add((*kbls.PointG1)(dst), (*kbls.PointG1)(a), (*kbls.PointG1)(b))
+}
+
+func SubG1(dst *G1Point, a *G1Point, b *G1Point) {
+	kbls.NewG1().Sub((*kbls.PointG1)(dst), (*kbls.PointG1)(a), (*kbls.PointG1)(b))
+}
+
+// StrG1 formats the point like mcl does: "0" for the point at infinity, "1 <x> <y>" otherwise, affine and decimal.
+func StrG1(v *G1Point) string {
+	var p kbls.PointG1
+	p.Set((*kbls.PointG1)(v))
+	g := kbls.NewG1()
+	if g.IsZero(&p) {
+		return "0"
+	}
+	return "1 " + decimalFps(g.ToBytes(&p))
+}
+
+func NegG1(dst *G1Point) {
+	kbls.NewG1().Neg((*kbls.PointG1)(dst), (*kbls.PointG1)(dst))
+}
+
+type G2Point kbls.PointG2
+
+func ClearG2(x *G2Point) {
+	(*kbls.PointG2)(x).Zero()
+}
+
+func CopyG2(dst *G2Point, v *G2Point) {
+	*dst = *v
+}
+
+func MulG2(dst *G2Point, a *G2Point, b *Fr) {
+	checkKilicCurve()
+	kbls.NewG2().MulScalarBig((*kbls.PointG2)(dst), (*kbls.PointG2)(a), frToBig(b))
+}
+
+func AddG2(dst *G2Point, a *G2Point, b *G2Point) {
+	kbls.NewG2().Add((*kbls.PointG2)(dst), (*kbls.PointG2)(a), (*kbls.PointG2)(b))
+}
+
+func SubG2(dst *G2Point, a *G2Point, b *G2Point) {
+	kbls.NewG2().Sub((*kbls.PointG2)(dst), (*kbls.PointG2)(a), (*kbls.PointG2)(b))
+}
+
+func NegG2(dst *G2Point) {
+	kbls.NewG2().Neg((*kbls.PointG2)(dst), (*kbls.PointG2)(dst))
+}
+
+// StrG2 formats the point like mcl does: "0" for the point at infinity,
+// "1 <x.a> <x.b> <y.a> <y.b>" otherwise, affine and decimal.
+func StrG2(v *G2Point) string {
+	var p kbls.PointG2
+	p.Set((*kbls.PointG2)(v))
+	g := kbls.NewG2()
+	if g.IsZero(&p) {
+		return "0"
+	}
+	b := g.ToBytes(&p)
+	// kilic encodes each Fp2 coordinate as (c1, c0), swap them to print (c0, c1) like mcl
+	return "1 " + decimalFps(b[48:96]) + " " + decimalFps(b[0:48]) + " " + decimalFps(b[144:192]) + " " + decimalFps(b[96:144])
+}
+
+// decimalFps formats a concatenation of big-endian 48 byte field elements as space separated decimals.
+func decimalFps(b []byte) string {
+	parts := make([]string, 0, len(b)/48)
+	for i := 0; i+48 <= len(b); i += 48 {
+		parts = append(parts, new(big.Int).SetBytes(b[i:i+48]).String())
+	}
+	return strings.Join(parts, " ")
+}
+
+func EqualG1(a *G1Point, b *G1Point) bool {
+	return kbls.NewG1().Equal((*kbls.PointG1)(a), (*kbls.PointG1)(b))
+}
+
+func EqualG2(a *G2Point, b *G2Point) bool {
+	return kbls.NewG2().Equal((*kbls.PointG2)(a), (*kbls.PointG2)(b))
+}
+
+func LinCombG1(numbers []G1Point, factors []Fr) *G1Point {
+	checkKilicCurve()
+	if len(numbers) != len(factors) {
+		panic("got LinCombG1 numbers/factors length mismatch")
+	}
+	var out G1Point
+	tmpG1s := make([]*kbls.PointG1, len(numbers), len(numbers))
+	for i := 0; i < len(numbers); i++ {
+		tmpG1s[i] = (*kbls.PointG1)(&numbers[i])
+	}
+	tmpFrs := make([]*big.Int, len(factors), len(factors))
+	for i := 0; i < len(factors); i++ {
+		tmpFrs[i] = frToBig(&factors[i])
+	}
+	_, _ = kbls.NewG1().MultiExpBig((*kbls.PointG1)(&out), tmpG1s, tmpFrs)
+	return &out
+}
+
+func LinCombG2(numbers []G2Point, factors []Fr) *G2Point {
+	checkKilicCurve()
+	if len(numbers) != len(factors) {
+		panic("got LinCombG2 numbers/factors length mismatch")
+	}
+	var out G2Point
+	tmpG2s := make([]*kbls.PointG2, len(numbers), len(numbers))
+	for i := 0; i < len(numbers); i++ {
+		tmpG2s[i] = (*kbls.PointG2)(&numbers[i])
+	}
+	tmpFrs := make([]*big.Int, len(factors), len(factors))
+	for i := 0; i < len(factors); i++ {
+		tmpFrs[i] = frToBig(&factors[i])
+	}
+	_, _ = kbls.NewG2().MultiExpBig((*kbls.PointG2)(&out), tmpG2s, tmpFrs)
+	return &out
+}
+
+// e(a1^(-1), a2) * e(b1,  b2) = 1_T
+func PairingsVerify(a1 *G1Point, a2 *G2Point, b1 *G1Point, b2 *G2Point) bool {
+	checkKilicCurve()
+	// the engine normalizes the points it is given in-place, so give it copies
+	var a1c, b1c kbls.PointG1
+	var a2c, b2c kbls.PointG2
+	a1c.Set((*kbls.PointG1)(a1))
+	a2c.Set((*kbls.PointG2)(a2))
+	b1c.Set((*kbls.PointG1)(b1))
+	b2c.Set((*kbls.PointG2)(b2))
+	pairingEngine := kbls.NewEngine()
+	pairingEngine.AddPairInv(&a1c, &a2c)
+	pairingEngine.AddPair(&b1c, &b2c)
+	return pairingEngine.Check()
+}
+
+// PairingProductIsOne checks e(g1s[0], g2s[0]) * e(g1s[1], g2s[1]) * ... = 1_T,
+// with a single multi-Miller loop and final exponentiation. An empty product is one.
+func PairingProductIsOne(g1s []G1Point, g2s []G2Point) bool {
+	checkKilicCurve()
+	if len(g1s) != len(g2s) {
+		panic("got PairingProductIsOne g1s/g2s length mismatch")
+	}
+	if len(g1s) == 0 {
+		return true
+	}
+	pairingEngine := kbls.NewEngine()
+	for i := range g1s {
+		// the engine normalizes the points it is given in-place, so give it copies
+		var p1 kbls.PointG1
+		var p2 kbls.PointG2
+		p1.Set((*kbls.PointG1)(&g1s[i]))
+		p2.Set((*kbls.PointG2)(&g2s[i]))
+		pairingEngine.AddPair(&p1, &p2)
+	}
+	return pairingEngine.Check()
+}
+
+func DebugG1s(msg string, values []G1Point) {
+	var out strings.Builder
+	for i := range values {
+		out.WriteString(fmt.Sprintf("%s %d: %s\n", msg, i, StrG1(&values[i])))
+	}
+	fmt.Println(out.String())
+}
+
+// kilic uses the ZCash format, see point_encoding.go. It normalizes points in-place, so give it copies.
+func g1ToUncompressed(p *G1Point) (out [G1UncompressedSize]byte) {
+	var tmp kbls.PointG1
+	tmp.Set((*kbls.PointG1)(p))
+	copy(out[:], kbls.NewG1().ToUncompressed(&tmp))
+	return
+}
+
+func g1FromUncompressedUnchecked(dst *G1Point, raw *[G1UncompressedSize]byte) error {
+	p, err := kbls.NewG1().FromBytes(raw[:])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPointNotOnCurve, err)
+	}
+	*dst = G1Point(*p)
+	return nil
+}
+
+func g1InSubgroup(p *G1Point) bool {
+	var tmp kbls.PointG1
+	tmp.Set((*kbls.PointG1)(p))
+	return kbls.NewG1().InCorrectSubgroup(&tmp)
+}
+
+func g2ToUncompressed(p *G2Point) (out [G2UncompressedSize]byte) {
+	var tmp kbls.PointG2
+	tmp.Set((*kbls.PointG2)(p))
+	copy(out[:], kbls.NewG2().ToUncompressed(&tmp))
+	return
+}
+
+func g2FromUncompressedUnchecked(dst *G2Point, raw *[G2UncompressedSize]byte) error {
+	p, err := kbls.NewG2().FromBytes(raw[:])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPointNotOnCurve, err)
+	}
+	*dst = G2Point(*p)
+	return nil
+}
+
+func g2InSubgroup(p *G2Point) bool {
+	var tmp kbls.PointG2
+	tmp.Set((*kbls.PointG2)(p))
+	return kbls.NewG2().InCorrectSubgroup(&tmp)
+}
+
+// GTElement is an element of the target group of the pairing, a subgroup of Fp12.
+type GTElement kbls.E
+
+func Pairing(dst *GTElement, a *G1Point, b *G2Point) {
+	checkKilicCurve()
+	// the engine normalizes the points it is given in-place, so give it copies
+	var p1 kbls.PointG1
+	var p2 kbls.PointG2
+	p1.Set((*kbls.PointG1)(a))
+	p2.Set((*kbls.PointG2)(b))
+	*dst = GTElement(*kbls.NewEngine().AddPair(&p1, &p2).Result())
+}
+
+func MulGT(dst *GTElement, a *GTElement, b *GTElement) {
+	kbls.NewGT().Mul((*kbls.E)(dst), (*kbls.E)(a), (*kbls.E)(b))
+}
+
+func ExpGT(dst *GTElement, a *GTElement, e *Fr) {
+	kbls.NewGT().Exp((*kbls.E)(dst), (*kbls.E)(a), frToBig(e))
+}
+
+func EqualGT(a *GTElement, b *GTElement) bool {
+	return (*kbls.E)(a).Equal((*kbls.E)(b))
+}
+
+func IsOneGT(v *GTElement) bool {
+	return (*kbls.E)(v).IsOne()
+}
+
+// GTToBytes encodes the GT element, see GTSize for the format.
+func GTToBytes(v *GTElement) (out [GTSize]byte) {
+	copy(out[:], kbls.NewGT().ToBytes((*kbls.E)(v)))
+	return
+}
+
+// GTFromBytes decodes a GT element, see GTSize for the format, and checks it is in the order r subgroup.
+func GTFromBytes(dst *GTElement, b []byte) error {
+	if len(b) != GTSize {
+		return fmt.Errorf("expected %d bytes, got %d", GTSize, len(b))
+	}
+	gt := kbls.NewGT()
+	out, err := gt.FromBytes(b)
+	if err != nil {
+		if out != nil {
+			// the coefficients were valid, the element is not in the subgroup
+			return ErrGTNotInSubgroup
+		}
+		return fmt.Errorf("%w: %v", ErrNonCanonicalCoordinate, err)
+	}
+	*dst = GTElement(*out)
+	return nil
+}
+
+func hashToG1(out *G1Point, msg, dst []byte) error {
+	p, err := kbls.NewG1().HashToCurve(msg, dst)
+	if err != nil {
+		return err
+	}
+	*out = G1Point(*p)
+	return nil
+}
+
+func hashToG2(out *G2Point, msg, dst []byte) error {
+	p, err := kbls.NewG2().HashToCurve(msg, dst)
+	if err != nil {
+		return err
+	}
+	*out = G2Point(*p)
+	return nil
+}
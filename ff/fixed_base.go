@@ -0,0 +1,122 @@
+package ff
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultFixedBaseWindow is the window size of the GenG1 and GenG2 tables:
+// 32 windows of 255 multiples each, so a multiplication costs at most 32 additions.
+const DefaultFixedBaseWindow = 8
+
+// Below this many scalars per worker, BatchMul does not split up the work further.
+const minFixedBaseChunk = 64
+
+// FixedBaseTable holds precomputed multiples of a fixed base point, so that multiplications of that base
+// only need additions: with windows of c bits, table[w][d-1] = d * 2^(w*c) * base.
+// A table is safe for concurrent use once constructed.
+type FixedBaseTable[P any] struct {
+	ops    pointOps[P]
+	window uint
+	table  [][]P
+}
+
+func newFixedBaseTable[P any](ops pointOps[P], base *P, window uint) (*FixedBaseTable[P], error) {
+	if window < 1 || window > 16 {
+		return nil, fmt.Errorf("window size must be between 1 and 16 bits, got %d", window)
+	}
+	windows := (msmScalarBits + window - 1) / window
+	t := &FixedBaseTable[P]{ops: ops, window: window, table: make([][]P, windows, windows)}
+	windowBase := *base
+	for w := range t.table {
+		row := make([]P, (1<<window)-1, (1<<window)-1)
+		row[0] = windowBase
+		for d := 1; d < len(row); d++ {
+			ops.add(&row[d], &row[d-1], &windowBase)
+		}
+		t.table[w] = row
+		// the next window base is 2^c times this one
+		ops.add(&windowBase, &row[len(row)-1], &windowBase)
+	}
+	return t, nil
+}
+
+// NewFixedBaseTableG1 precomputes a table of multiples of the G1 base, with windows of the given number of bits (1 to 16).
+// Larger windows make multiplications faster, at the cost of (2^window - 1) * ceil(256 / window) precomputed points.
+func NewFixedBaseTableG1(base *G1Point, window uint) (*FixedBaseTable[G1Point], error) {
+	return newFixedBaseTable(g1Ops, base, window)
+}
+
+// NewFixedBaseTableG2 is like NewFixedBaseTableG1, for a G2 base.
+func NewFixedBaseTableG2(base *G2Point, window uint) (*FixedBaseTable[G2Point], error) {
+	return newFixedBaseTable(g2Ops, base, window)
+}
+
+// Mul sets dst to scalar * base.
+func (t *FixedBaseTable[P]) Mul(dst *P, scalar *Fr) {
+	s := FrTo32BE(scalar)
+	var out P
+	t.ops.clear(&out)
+	for w := range t.table {
+		if d := msmDigit(&s, uint(w)*t.window, t.window); d != 0 {
+			t.ops.add(&out, &out, &t.table[w][d-1])
+		}
+	}
+	*dst = out
+}
+
+// BatchMul sets dst[i] to scalars[i] * base, with the scalars split into chunks that are multiplied concurrently.
+// If workers <= 0, runtime.NumCPU() workers are used.
+func (t *FixedBaseTable[P]) BatchMul(dst []P, scalars []Fr, workers int) error {
+	if len(dst) != len(scalars) {
+		return fmt.Errorf("dst length %d does not match scalars length %d", len(dst), len(scalars))
+	}
+	ParallelRange(len(scalars), workers, minFixedBaseChunk, func(start, end int) {
+		for i := start; i < end; i++ {
+			t.Mul(&dst[i], &scalars[i])
+		}
+	})
+	return nil
+}
+
+var (
+	genTablesLock sync.Mutex
+	genG1Table    *FixedBaseTable[G1Point]
+	genG2Table    *FixedBaseTable[G2Point]
+)
+
+// GenG1Table returns the table of multiples of GenG1, with DefaultFixedBaseWindow. It is computed on first use.
+func GenG1Table() *FixedBaseTable[G1Point] {
+	genTablesLock.Lock()
+	defer genTablesLock.Unlock()
+	if genG1Table == nil {
+		t, err := NewFixedBaseTableG1(&GenG1, DefaultFixedBaseWindow)
+		if err != nil {
+			panic(err)
+		}
+		genG1Table = t
+	}
+	return genG1Table
+}
+
+// GenG2Table returns the table of multiples of GenG2, with DefaultFixedBaseWindow. It is computed on first use.
+func GenG2Table() *FixedBaseTable[G2Point] {
+	genTablesLock.Lock()
+	defer genTablesLock.Unlock()
+	if genG2Table == nil {
+		t, err := NewFixedBaseTableG2(&GenG2, DefaultFixedBaseWindow)
+		if err != nil {
+			panic(err)
+		}
+		genG2Table = t
+	}
+	return genG2Table
+}
+
+// resetGenTables drops the GenG1 and GenG2 tables, after the generators changed with SetCurve.
+func resetGenTables() {
+	genTablesLock.Lock()
+	defer genTablesLock.Unlock()
+	genG1Table = nil
+	genG2Table = nil
+}
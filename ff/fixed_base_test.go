@@ -0,0 +1,83 @@
+package ff
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFixedBaseTableG1(t *testing.T) {
+	rng := NewSeededReader(17)
+	base, err := RandomG1From(rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scalars := make([]Fr, 200, 200)
+	for i := range scalars {
+		x, err := RandomFrFrom(rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		CopyFr(&scalars[i], x)
+	}
+	CopyFr(&scalars[0], &ZERO)
+	CopyFr(&scalars[1], &ONE)
+	CopyFr(&scalars[2], &MODULUS_MINUS1)
+	for _, window := range []uint{1, 5, 8} {
+		table, err := NewFixedBaseTableG1(base, window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := make([]G1Point, len(scalars), len(scalars))
+		if err := table.BatchMul(out, scalars, 0); err != nil {
+			t.Fatal(err)
+		}
+		var expected G1Point
+		for i := range scalars {
+			MulG1(&expected, base, &scalars[i])
+			if !EqualG1(&out[i], &expected) {
+				t.Fatalf("window %d, scalar %d: got %s, expected %s", window, i, StrG1(&out[i]), StrG1(&expected))
+			}
+		}
+	}
+	if _, err := NewFixedBaseTableG1(base, 0); err == nil {
+		t.Fatal("expected error for window size 0")
+	}
+	if err := GenG1Table().BatchMul(make([]G1Point, 1), scalars, 0); err == nil {
+		t.Fatal("expected length mismatch error")
+	}
+}
+
+func TestGenTables(t *testing.T) {
+	x := RandomFr()
+	var got, expected G1Point
+	GenG1Table().Mul(&got, x)
+	MulG1(&expected, &GenG1, x)
+	if !EqualG1(&got, &expected) {
+		t.Fatalf("G1: got %s, expected %s", StrG1(&got), StrG1(&expected))
+	}
+	var got2, expected2 G2Point
+	GenG2Table().Mul(&got2, x)
+	MulG2(&expected2, &GenG2, x)
+	if !EqualG2(&got2, &expected2) {
+		t.Fatalf("G2: got %s, expected %s", StrG2(&got2), StrG2(&expected2))
+	}
+}
+
+func BenchmarkFixedBaseTableG1(b *testing.B) {
+	for scale := uint8(8); scale <= 14; scale += 2 {
+		n := 1 << scale
+		scalars := make([]Fr, n, n)
+		for i := range scalars {
+			CopyFr(&scalars[i], RandomFr())
+		}
+		out := make([]G1Point, n, n)
+		table := GenG1Table()
+		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := table.BatchMul(out, scalars, 0); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package ff
+
+import "testing"
+
+func TestPairingsVerify(t *testing.T) {
+	x := RandomFr()
+	var xG1 G1Point
+	MulG1(&xG1, &GenG1, x)
+	var xG2 G2Point
+	MulG2(&xG2, &GenG2, x)
+
+	// e([x]_1, [1]_2) = e([1]_1, [x]_2)
+	if !PairingsVerify(&xG1, &GenG2, &GenG1, &xG2) {
+		t.Fatal("expected pairing check to pass")
+	}
+	var wrong G2Point
+	AddG2(&wrong, &xG2, &GenG2)
+	if PairingsVerify(&xG1, &GenG2, &GenG1, &wrong) {
+		t.Fatal("expected pairing check to fail")
+	}
+}
+
+func TestLinCombG1(t *testing.T) {
+	n := 17
+	points := make([]G1Point, n, n)
+	factors := make([]Fr, n, n)
+	var expected G1Point
+	ClearG1(&expected)
+	var tmp G1Point
+	for i := 0; i < n; i++ {
+		MulG1(&points[i], &GenG1, RandomFr())
+		CopyFr(&factors[i], RandomFr())
+		MulG1(&tmp, &points[i], &factors[i])
+		AddG1(&expected, &expected, &tmp)
+	}
+	if got := LinCombG1(points, factors); !EqualG1(got, &expected) {
+		t.Fatalf("got %s, expected %s", StrG1(got), StrG1(&expected))
+	}
+}
+
+func TestPairingProductIsOne(t *testing.T) {
+	if !PairingProductIsOne(nil, nil) {
+		t.Fatal("expected empty product to be one")
+	}
+	n := 12
+	g1s := make([]G1Point, n, n)
+	g2s := make([]G2Point, n, n)
+	// e(a_0, b_0) * ... * e(a_(n-2), b_(n-2)) * e(-sum(a_i * b_i), 1) = 1_T
+	var sum, tmp Fr
+	CopyFr(&sum, &ZERO)
+	for i := 0; i < n-1; i++ {
+		a, b := RandomFr(), RandomFr()
+		MulG1(&g1s[i], &GenG1, a)
+		MulG2(&g2s[i], &GenG2, b)
+		MulModFr(&tmp, a, b)
+		AddModFr(&sum, &sum, &tmp)
+	}
+	NegModFr(&sum, &sum)
+	MulG1(&g1s[n-1], &GenG1, &sum)
+	CopyG2(&g2s[n-1], &GenG2)
+	if !PairingProductIsOne(g1s, g2s) {
+		t.Fatal("expected pairing product to be one")
+	}
+	AddG1(&g1s[3], &g1s[3], &GenG1)
+	if PairingProductIsOne(g1s, g2s) {
+		t.Fatal("expected pairing product not to be one")
+	}
+}
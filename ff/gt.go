@@ -0,0 +1,11 @@
+package ff
+
+import "errors"
+
+// GTSize is the size of an encoded GT element: 12 big-endian base field elements of 48 bytes.
+// The Fp12 coefficients are ordered from the highest to the lowest, c1.c2.c1 | c1.c2.c0 | c1.c1.c1 | ... | c0.c0.c0,
+// like gnark-crypto and kilic do. Only BLS12-381 is supported.
+const GTSize = 576
+
+// ErrGTNotInSubgroup is returned when decoding a GT element that is not in the order r subgroup of Fp12.
+var ErrGTNotInSubgroup = errors.New("GT element is not in the order r subgroup")
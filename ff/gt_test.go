@@ -0,0 +1,93 @@
+package ff
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestGT(t *testing.T) {
+	a, b := RandomFr(), RandomFr()
+	var aG1 G1Point
+	MulG1(&aG1, &GenG1, a)
+	var bG2 G2Point
+	MulG2(&bG2, &GenG2, b)
+
+	var base, left, right GTElement
+	Pairing(&base, &GenG1, &GenG2)
+	if IsOneGT(&base) {
+		t.Fatal("expected the pairing of the generators not to be one")
+	}
+	// e(a*G1, b*G2) = e(G1, G2)^(a*b)
+	Pairing(&left, &aG1, &bG2)
+	var ab Fr
+	MulModFr(&ab, a, b)
+	ExpGT(&right, &base, &ab)
+	if !EqualGT(&left, &right) {
+		t.Fatal("pairing is not bilinear")
+	}
+	// e(a*G1, G2) * e(G1, b*G2) = e(G1, G2)^(a+b)
+	var x, y GTElement
+	Pairing(&x, &aG1, &GenG2)
+	Pairing(&y, &GenG1, &bG2)
+	MulGT(&left, &x, &y)
+	var aPlusB Fr
+	AddModFr(&aPlusB, a, b)
+	ExpGT(&right, &base, &aPlusB)
+	if !EqualGT(&left, &right) {
+		t.Fatal("bad GT multiplication")
+	}
+	// e(G1, G2)^r = 1
+	ExpGT(&left, &base, &MODULUS_MINUS1)
+	MulGT(&left, &left, &base)
+	if !IsOneGT(&left) {
+		t.Fatal("expected element of order r")
+	}
+	Pairing(&left, &ZeroG1, &GenG2)
+	if !IsOneGT(&left) {
+		t.Fatal("expected pairing with infinity to be one")
+	}
+}
+
+func TestGTEncoding(t *testing.T) {
+	var base GTElement
+	Pairing(&base, &GenG1, &GenG2)
+	enc := GTToBytes(&base)
+	// the same for all backends
+	h := sha256.Sum256(enc[:])
+	if got, expected := hex.EncodeToString(h[:]), "300e47c99502f3af33ad2080847d528cabd90365a90ab98bc174565c27928591"; got != expected {
+		t.Fatalf("got e(G1, G2) encoding hash %s, expected %s", got, expected)
+	}
+	var got GTElement
+	if err := GTFromBytes(&got, enc[:]); err != nil {
+		t.Fatal(err)
+	}
+	if !EqualGT(&got, &base) {
+		t.Fatal("bad GT roundtrip")
+	}
+	var x GTElement
+	ExpGT(&x, &base, RandomFr())
+	enc = GTToBytes(&x)
+	if err := GTFromBytes(&got, enc[:]); err != nil {
+		t.Fatal(err)
+	}
+	if !EqualGT(&got, &x) {
+		t.Fatal("bad GT roundtrip")
+	}
+
+	if err := GTFromBytes(&got, enc[:GTSize-1]); err == nil {
+		t.Fatal("expected error for short input")
+	}
+	// 2, as an element of Fp12, does not have order r
+	var two [GTSize]byte
+	two[GTSize-1] = 2
+	if err := GTFromBytes(&got, two[:]); !errors.Is(err, ErrGTNotInSubgroup) {
+		t.Fatalf("got error %v, expected %v", err, ErrGTNotInSubgroup)
+	}
+	bad := enc
+	fpModulus.FillBytes(bad[:48])
+	if err := GTFromBytes(&got, bad[:]); !errors.Is(err, ErrNonCanonicalCoordinate) {
+		t.Fatalf("got error %v, expected %v", err, ErrNonCanonicalCoordinate)
+	}
+}
@@ -0,0 +1,19 @@
+package ff
+
+// HashToG1 hashes the message to a G1 point, following the BLS12381G1_XMD:SHA-256_SSWU_RO_ suite of RFC 9380.
+// The dst is the domain separation tag, and must be unique for each use. Only BLS12-381 is supported.
+func HashToG1(out *G1Point, msg, dst []byte) error {
+	if currentCurve != BLS12_381 {
+		return ErrUnsupportedCurve
+	}
+	return hashToG1(out, msg, dst)
+}
+
+// HashToG2 hashes the message to a G2 point, following the BLS12381G2_XMD:SHA-256_SSWU_RO_ suite of RFC 9380.
+// The dst is the domain separation tag, and must be unique for each use. Only BLS12-381 is supported.
+func HashToG2(out *G2Point, msg, dst []byte) error {
+	if currentCurve != BLS12_381 {
+		return ErrUnsupportedCurve
+	}
+	return hashToG2(out, msg, dst)
+}
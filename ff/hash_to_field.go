@@ -0,0 +1,89 @@
+package ff
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Bytes per hashed field element for hash_to_field, L = ceil((ceil(log2(r)) + k) / 8) with k = 128.
+const hashToFrBytes = 48
+
+// ExpandMessageXMD implements expand_message_xmd with SHA-256, RFC 9380 section 5.3.1.
+// A domain separation tag longer than 255 bytes is first hashed, as in section 5.3.3.
+func ExpandMessageXMD(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if len(dst) > 255 {
+		h := sha256.New()
+		h.Write([]byte("H2C-OVERSIZE-DST-"))
+		h.Write(dst)
+		dst = h.Sum(nil)
+	}
+	ell := (lenInBytes + sha256.Size - 1) / sha256.Size
+	if ell > 255 || lenInBytes > 65535 {
+		return nil, fmt.Errorf("cannot expand message to %d bytes", lenInBytes)
+	}
+	if lenInBytes <= 0 {
+		return nil, errors.New("expanded message length must be positive")
+	}
+	// DST_prime = DST || I2OSP(len(DST), 1)
+	dstPrime := append(append(make([]byte, 0, len(dst)+1), dst...), byte(len(dst)))
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	h := sha256.New()
+	h.Write(make([]byte, h.BlockSize()))
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	out := make([]byte, 0, ell*sha256.Size)
+	out = append(out, bi...)
+	for i := 2; i <= ell; i++ {
+		// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
+		tmp := make([]byte, sha256.Size)
+		for j := range tmp {
+			tmp[j] = b0[j] ^ bi[j]
+		}
+		h.Reset()
+		h.Write(tmp)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		out = append(out, bi...)
+	}
+	return out[:lenInBytes], nil
+}
+
+// HashToFrs hashes the message to count field elements, with hash_to_field from RFC 9380 section 5.2,
+// using expand_message_xmd with SHA-256, and 48 bytes per element (k = 128 bits of security).
+// The dst is the domain separation tag, and must be unique for each use.
+func HashToFrs(msg, dst []byte, count int) ([]Fr, error) {
+	uniform, err := ExpandMessageXMD(msg, dst, count*hashToFrBytes)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Fr, count, count)
+	for i := range out {
+		v := new(big.Int).SetBytes(uniform[i*hashToFrBytes : (i+1)*hashToFrBytes])
+		v.Mod(v, &currentModulus)
+		frFromBig(&out[i], v)
+	}
+	return out, nil
+}
+
+// HashToFr hashes the message to a single field element, see HashToFrs.
+func HashToFr(msg, dst []byte) (Fr, error) {
+	out, err := HashToFrs(msg, dst, 1)
+	if err != nil {
+		return Fr{}, err
+	}
+	return out[0], nil
+}
@@ -0,0 +1,388 @@
+package ff
+
+import "math/big"
+
+// A math/big implementation of the BLS12-381 hash_to_curve suites of RFC 9380, for the backends whose
+// library does not provide them without changing process-global settings. It is slow, compared to native
+// implementations, but does not depend on the backend for anything but decoding the resulting point.
+
+// Bytes per hashed base field element, L = ceil((ceil(log2(p)) + k) / 8) with k = 128.
+const hashToFpBytes = 64
+
+// field is the Fp or Fp2 arithmetic that the simplified SWU map, the isogenies and the cofactor clearing need.
+type field[E any] interface {
+	fromUint64(v uint64) E
+	add(a, b E) E
+	sub(a, b E) E
+	mul(a, b E) E
+	// inv maps zero to zero, like inv0 in RFC 9380.
+	inv(a E) E
+	// sqrt returns a square root of a, and false if a is not a square.
+	sqrt(a E) (E, bool)
+	sgn0(a E) uint
+	isZero(a E) bool
+	equal(a, b E) bool
+}
+
+type fpField struct{}
+
+func (fpField) fromUint64(v uint64) *big.Int { return new(big.Int).SetUint64(v) }
+func (fpField) add(a, b *big.Int) *big.Int   { return fpAdd(a, b) }
+func (fpField) sub(a, b *big.Int) *big.Int   { return fpSub(a, b) }
+func (fpField) mul(a, b *big.Int) *big.Int   { return fpMul(a, b) }
+func (fpField) sgn0(a *big.Int) uint         { return a.Bit(0) }
+func (fpField) isZero(a *big.Int) bool       { return a.Sign() == 0 }
+func (fpField) equal(a, b *big.Int) bool     { return a.Cmp(b) == 0 }
+
+func (fpField) inv(a *big.Int) *big.Int {
+	if a.Sign() == 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).ModInverse(a, fpModulus)
+}
+
+func (fpField) sqrt(a *big.Int) (*big.Int, bool) {
+	r := fpSqrt(a)
+	return r, r != nil
+}
+
+type fp2Field struct{}
+
+func (fp2Field) fromUint64(v uint64) fp2 { return fp2{c0: new(big.Int).SetUint64(v), c1: new(big.Int)} }
+func (fp2Field) add(a, b fp2) fp2        { return a.add(b) }
+func (fp2Field) sub(a, b fp2) fp2        { return fp2{c0: fpSub(a.c0, b.c0), c1: fpSub(a.c1, b.c1)} }
+func (fp2Field) mul(a, b fp2) fp2        { return a.mul(b) }
+func (fp2Field) isZero(a fp2) bool       { return a.c0.Sign() == 0 && a.c1.Sign() == 0 }
+func (fp2Field) equal(a, b fp2) bool     { return a.equal(b) }
+
+func (fp2Field) inv(a fp2) fp2 {
+	// 1 / (a0 + a1*u) = (a0 - a1*u) / (a0^2 + a1^2)
+	n := fpField{}.inv(fpAdd(fpMul(a.c0, a.c0), fpMul(a.c1, a.c1)))
+	return fp2{c0: fpMul(a.c0, n), c1: fpNeg(fpMul(a.c1, n))}
+}
+
+func (fp2Field) sqrt(a fp2) (fp2, bool) {
+	r := a.sqrt()
+	if r == nil {
+		return fp2{}, false
+	}
+	return *r, true
+}
+
+// sgn0 for m = 2, RFC 9380 section 4.1: the sign of c0, or of c1 if c0 is zero.
+func (fp2Field) sgn0(a fp2) uint {
+	if a.c0.Sign() == 0 {
+		return a.c1.Bit(0)
+	}
+	return a.c0.Bit(0)
+}
+
+// sswu is the simplified Shallue-van de Woestijne-Ulas map to y^2 = x^3 + a*x + b, RFC 9380 section 6.6.2.
+func sswu[E any](f field[E], u, a, b, z E) (x, y E) {
+	zu2 := f.mul(z, f.mul(u, u))
+	// tv1 = inv0(Z^2 * u^4 + Z * u^2)
+	tv1 := f.inv(f.add(f.mul(zu2, zu2), zu2))
+	var x1 E
+	if f.isZero(tv1) {
+		// x1 = B / (Z * A)
+		x1 = f.mul(b, f.inv(f.mul(z, a)))
+	} else {
+		// x1 = (-B / A) * (1 + tv1)
+		x1 = f.mul(f.mul(f.sub(f.fromUint64(0), b), f.inv(a)), f.add(f.fromUint64(1), tv1))
+	}
+	g := func(x E) E {
+		return f.add(f.mul(f.add(f.mul(x, x), a), x), b)
+	}
+	x = x1
+	y, ok := f.sqrt(g(x1))
+	if !ok {
+		x = f.mul(zu2, x1)
+		if y, ok = f.sqrt(g(x)); !ok {
+			panic("simplified SWU: neither g(x1) nor g(x2) is a square")
+		}
+	}
+	if f.sgn0(u) != f.sgn0(y) {
+		y = f.sub(f.fromUint64(0), y)
+	}
+	return x, y
+}
+
+// isogeny evaluates the rational maps x = xNum(x') / xDen(x'), y = y' * yNum(x') / yDen(x').
+// The coefficients are in increasing degree, in the order xNum, xDen, yNum, yDen.
+// False is returned if a denominator is zero, and the image is the point at infinity.
+func isogeny[E any](f field[E], k *[4][]E, x, y E) (E, E, bool) {
+	var v [4]E
+	for i := range k {
+		v[i] = k[i][len(k[i])-1]
+		for j := len(k[i]) - 2; j >= 0; j-- {
+			v[i] = f.add(f.mul(v[i], x), k[i][j])
+		}
+	}
+	if f.isZero(v[1]) || f.isZero(v[3]) {
+		return x, y, false
+	}
+	return f.mul(v[0], f.inv(v[1])), f.mul(y, f.mul(v[2], f.inv(v[3]))), true
+}
+
+// affinePoint is a point on a curve y^2 = x^3 + b, inf marks the point at infinity.
+type affinePoint[E any] struct {
+	x, y E
+	inf  bool
+}
+
+func pointDouble[E any](f field[E], p affinePoint[E]) affinePoint[E] {
+	if p.inf || f.isZero(p.y) {
+		return affinePoint[E]{inf: true}
+	}
+	// lambda = 3x^2 / 2y
+	lambda := f.mul(f.mul(f.fromUint64(3), f.mul(p.x, p.x)), f.inv(f.add(p.y, p.y)))
+	x := f.sub(f.mul(lambda, lambda), f.add(p.x, p.x))
+	return affinePoint[E]{x: x, y: f.sub(f.mul(lambda, f.sub(p.x, x)), p.y)}
+}
+
+func pointAdd[E any](f field[E], p, q affinePoint[E]) affinePoint[E] {
+	if p.inf {
+		return q
+	}
+	if q.inf {
+		return p
+	}
+	if f.equal(p.x, q.x) {
+		if f.equal(p.y, q.y) {
+			return pointDouble(f, p)
+		}
+		return affinePoint[E]{inf: true}
+	}
+	lambda := f.mul(f.sub(q.y, p.y), f.inv(f.sub(q.x, p.x)))
+	x := f.sub(f.sub(f.mul(lambda, lambda), p.x), q.x)
+	return affinePoint[E]{x: x, y: f.sub(f.mul(lambda, f.sub(p.x, x)), p.y)}
+}
+
+// pointMul multiplies by the integer k, without assuming the point is in the prime order subgroup.
+func pointMul[E any](f field[E], p affinePoint[E], k *big.Int) affinePoint[E] {
+	out := affinePoint[E]{inf: true}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		out = pointDouble(f, out)
+		if k.Bit(i) == 1 {
+			out = pointAdd(f, out, p)
+		}
+	}
+	return out
+}
+
+// hashToFps is hash_to_field for count elements of Fp^m, with expand_message_xmd and SHA-256.
+// Each element is returned as its m coordinates.
+func hashToFps(msg, dst []byte, count, m int) ([][]*big.Int, error) {
+	uniform, err := ExpandMessageXMD(msg, dst, count*m*hashToFpBytes)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]*big.Int, count, count)
+	for i := range out {
+		out[i] = make([]*big.Int, m, m)
+		for j := range out[i] {
+			offset := (i*m + j) * hashToFpBytes
+			v := new(big.Int).SetBytes(uniform[offset : offset+hashToFpBytes])
+			out[i][j] = v.Mod(v, fpModulus)
+		}
+	}
+	return out, nil
+}
+
+func fpHex(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok || v.Cmp(fpModulus) >= 0 {
+		panic("bad base field constant " + s)
+	}
+	return v
+}
+
+func fpHexes(s ...string) []*big.Int {
+	out := make([]*big.Int, len(s), len(s))
+	for i := range s {
+		out[i] = fpHex(s[i])
+	}
+	return out
+}
+
+// The map to the curve E1': y^2 = x^3 + A'x + B', 11-isogenous to G1, RFC 9380 section 8.8.1.
+var (
+	g1IsoA = fpHex("144698a3b8e9433d693a02c96d4982b0ea985383ee66a8d8e8981aefd881ac98936f8da0e0f97f5cf428082d584c1d")
+	g1IsoB = fpHex("12e2908d11688030018b12e8753eee3b2016c1f0f24f4070a0b9c14fcef35ef55a23215a316ceaa5d1cc48e98e172be0")
+	g1IsoZ = big.NewInt(11)
+	// h_eff = 1 - z, with the BLS parameter z
+	g1HEff, _ = new(big.Int).SetString("d201000000010001", 16)
+)
+
+// The 11-isogeny map constants of RFC 9380 appendix E.2, with the implicit leading 1 of the denominators.
+var g1Isogeny = [4][]*big.Int{
+	fpHexes(
+		"11a05f2b1e833340b809101dd99815856b303e88a2d7005ff2627b56cdb4e2c85610c2d5f2e62d6eaeac1662734649b7",
+		"17294ed3e943ab2f0588bab22147a81c7c17e75b2f6a8417f565e33c70d1e86b4838f2a6f318c356e834eef1b3cb83bb",
+		"d54005db97678ec1d1048c5d10a9a1bce032473295983e56878e501ec68e25c958c3e3d2a09729fe0179f9dac9edcb0",
+		"1778e7166fcc6db74e0609d307e55412d7f5e4656a8dbf25f1b33289f1b330835336e25ce3107193c5b388641d9b6861",
+		"e99726a3199f4436642b4b3e4118e5499db995a1257fb3f086eeb65982fac18985a286f301e77c451154ce9ac8895d9",
+		"1630c3250d7313ff01d1201bf7a74ab5db3cb17dd952799b9ed3ab9097e68f90a0870d2dcae73d19cd13c1c66f652983",
+		"d6ed6553fe44d296a3726c38ae652bfb11586264f0f8ce19008e218f9c86b2a8da25128c1052ecaddd7f225a139ed84",
+		"17b81e7701abdbe2e8743884d1117e53356de5ab275b4db1a682c62ef0f2753339b7c8f8c8f475af9ccb5618e3f0c88e",
+		"80d3cf1f9a78fc47b90b33563be990dc43b756ce79f5574a2c596c928c5d1de4fa295f296b74e956d71986a8497e317",
+		"169b1f8e1bcfa7c42e0c37515d138f22dd2ecb803a0c5c99676314baf4bb1b7fa3190b2edc0327797f241067be390c9e",
+		"10321da079ce07e272d8ec09d2565b0dfa7dccdde6787f96d50af36003b14866f69b771f8c285decca67df3f1605fb7b",
+		"6e08c248e260e70bd1e962381edee3d31d79d7e22c837bc23c0bf1bc24c6b68c24b1b80b64d391fa9c8ba2e8ba2d229",
+	),
+	fpHexes(
+		"8ca8d548cff19ae18b2e62f4bd3fa6f01d5ef4ba35b48ba9c9588617fc8ac62b558d681be343df8993cf9fa40d21b1c",
+		"12561a5deb559c4348b4711298e536367041e8ca0cf0800c0126c2588c48bf5713daa8846cb026e9e5c8276ec82b3bff",
+		"b2962fe57a3225e8137e629bff2991f6f89416f5a718cd1fca64e00b11aceacd6a3d0967c94fedcfcc239ba5cb83e19",
+		"3425581a58ae2fec83aafef7c40eb545b08243f16b1655154cca8abc28d6fd04976d5243eecf5c4130de8938dc62cd8",
+		"13a8e162022914a80a6f1d5f43e7a07dffdfc759a12062bb8d6b44e833b306da9bd29ba81f35781d539d395b3532a21e",
+		"e7355f8e4e667b955390f7f0506c6e9395735e9ce9cad4d0a43bcef24b8982f7400d24bc4228f11c02df9a29f6304a5",
+		"772caacf16936190f3e0c63e0596721570f5799af53a1894e2e073062aede9cea73b3538f0de06cec2574496ee84a3a",
+		"14a7ac2a9d64a8b230b3f5b074cf01996e7f63c21bca68a81996e1cdf9822c580fa5b9489d11e2d311f7d99bbdcc5a5e",
+		"a10ecf6ada54f825e920b3dafc7a3cce07f8d1d7161366b74100da67f39883503826692abba43704776ec3a79a1d641",
+		"95fc13ab9e92ad4476d6e3eb3a56680f682b4ee96f7d03776df533978f31c1593174e4b4b7865002d6384d168ecdd0a",
+		"1",
+	),
+	fpHexes(
+		"90d97c81ba24ee0259d1f094980dcfa11ad138e48a869522b52af6c956543d3cd0c7aee9b3ba3c2be9845719707bb33",
+		"134996a104ee5811d51036d776fb46831223e96c254f383d0f906343eb67ad34d6c56711962fa8bfe097e75a2e41c696",
+		"cc786baa966e66f4a384c86a3b49942552e2d658a31ce2c344be4b91400da7d26d521628b00523b8dfe240c72de1f6",
+		"1f86376e8981c217898751ad8746757d42aa7b90eeb791c09e4a3ec03251cf9de405aba9ec61deca6355c77b0e5f4cb",
+		"8cc03fdefe0ff135caf4fe2a21529c4195536fbe3ce50b879833fd221351adc2ee7f8dc099040a841b6daecf2e8fedb",
+		"16603fca40634b6a2211e11db8f0a6a074a7d0d4afadb7bd76505c3d3ad5544e203f6326c95a807299b23ab13633a5f0",
+		"4ab0b9bcfac1bbcb2c977d027796b3ce75bb8ca2be184cb5231413c4d634f3747a87ac2460f415ec961f8855fe9d6f2",
+		"987c8d5333ab86fde9926bd2ca6c674170a05bfe3bdd81ffd038da6c26c842642f64550fedfe935a15e4ca31870fb29",
+		"9fc4018bd96684be88c9e221e4da1bb8f3abd16679dc26c1e8b6e6a1f20cabe69d65201c78607a360370e577bdba587",
+		"e1bba7a1186bdb5223abde7ada14a23c42a0ca7915af6fe06985e7ed1e4d43b9b3f7055dd4eba6f2bafaaebca731c30",
+		"19713e47937cd1be0dfd0b8f1d43fb93cd2fcbcb6caf493fd1183e416389e61031bf3a5cce3fbafce813711ad011c132",
+		"18b46a908f36f6deb918c143fed2edcc523559b8aaf0c2462e6bfe7f911f643249d9cdf41b44d606ce07c8a4d0074d8e",
+		"b182cac101b9399d155096004f53f447aa7b12a3426b08ec02710e807b4633f06c851c1919211f20d4c04f00b971ef8",
+		"245a394ad1eca9b72fc00ae7be315dc757b3b080d4c158013e6632d3c40659cc6cf90ad1c232a6442d9d3f5db980133",
+		"5c129645e44cf1102a159f748c4a3fc5e673d81d7e86568d9ab0f5d396a7ce46ba1049b6579afb7866b1e715475224b",
+		"15e6be4e990f03ce4ea50b3b42df2eb5cb181d8f84965a3957add4fa95af01b2b665027efec01c7704b456be69c8b604",
+	),
+	fpHexes(
+		"16112c4c3a9c98b252181140fad0eae9601a6de578980be6eec3232b5be72e7a07f3688ef60c206d01479253b03663c1",
+		"1962d75c2381201e1a0cbd6c43c348b885c84ff731c4d59ca4a10356f453e01f78a4260763529e3532f6102c2e49a03d",
+		"58df3306640da276faaae7d6e8eb15778c4855551ae7f310c35a5dd279cd2eca6757cd636f96f891e2538b53dbf67f2",
+		"16b7d288798e5395f20d23bf89edb4d1d115c5dbddbcd30e123da489e726af41727364f2c28297ada8d26d98445f5416",
+		"be0e079545f43e4b00cc912f8228ddcc6d19c9f0f69bbb0542eda0fc9dec916a20b15dc0fd2ededda39142311a5001d",
+		"8d9e5297186db2d9fb266eaac783182b70152c65550d881c5ecd87b6f0f5a6449f38db9dfa9cce202c6477faaf9b7ac",
+		"166007c08a99db2fc3ba8734ace9824b5eecfdfa8d0cf8ef5dd365bc400a0051d5fa9c01a58b1fb93d1a1399126a775c",
+		"16a3ef08be3ea7ea03bcddfabba6ff6ee5a4375efa1f4fd7feb34fd206357132b920f5b00801dee460ee415a15812ed9",
+		"1866c8ed336c61231a1be54fd1d74cc4f9fb0ce4c6af5920abc5750c4bf39b4852cfe2f7bb9248836b233d9d55535d4a",
+		"167a55cda70a6e1cea820597d94a84903216f763e13d87bb5308592e7ea7d4fbc7385ea3d529b35e346ef48bb8913f55",
+		"4d2f259eea405bd48f010a01ad2911d9c6dd039bb61a6290e591b36e636a5c871a5c29f4f83060400f8b49cba8f6aa8",
+		"accbb67481d033ff5852c1e48c50c477f94ff8aefce42d28c0f9a88cea7913516f968986f7ebbea9684b529e2561092",
+		"ad6b9514c767fe3c3613144b45f1496543346d98adf02267d5ceef9a00d9b8693000763e3b90ac11e99b138573345cc",
+		"2660400eb2e4f3b628bdd0d53cd76f2bf565b94e72927c1cb748df27942480e420517bd8714cc80d1fadc1326ed06f7",
+		"e0fa1d816ddc03e6b24255e0d7819c171c40f65e273b853324efcd6356caa205ca2f570f13497804415473a1d634b8f",
+		"1",
+	),
+}
+
+// The map to the curve E2': y^2 = x^3 + A'x + B', 3-isogenous to G2, RFC 9380 section 8.8.2.
+var (
+	g2IsoA = fp2{c0: new(big.Int), c1: big.NewInt(240)}
+	g2IsoB = fp2{c0: big.NewInt(1012), c1: big.NewInt(1012)}
+	// Z = -(2 + u)
+	g2IsoZ = fp2{c0: fpNeg(big.NewInt(2)), c1: fpNeg(big.NewInt(1))}
+	// h_eff = 3(z^2 - 1) * h2, with the BLS parameter z and the G2 cofactor h2
+	g2HEff, _ = new(big.Int).SetString("bc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551", 16)
+)
+
+func fp2Hex(c0, c1 string) fp2 {
+	return fp2{c0: fpHex(c0), c1: fpHex(c1)}
+}
+
+// The 3-isogeny map constants of RFC 9380 appendix E.3, with the implicit leading 1 of the denominators.
+var g2Isogeny = [4][]fp2{
+	{
+		fp2Hex("5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6",
+			"5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6"),
+		fp2Hex("0",
+			"11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71a"),
+		fp2Hex("11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71e",
+			"8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38d"),
+		fp2Hex("171d6541fa38ccfaed6dea691f5fb614cb14b4e7f4e810aa22d6108f142b85757098e38d0f671c7188e2aaaaaaaa5ed1",
+			"0"),
+	},
+	{
+		fp2Hex("0",
+			"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa63"),
+		fp2Hex("c",
+			"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa9f"),
+		fp2Hex("1", "0"),
+	},
+	{
+		fp2Hex("1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706",
+			"1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706"),
+		fp2Hex("0",
+			"5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97be"),
+		fp2Hex("11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71c",
+			"8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38f"),
+		fp2Hex("124c9ad43b6cf79bfbf7043de3811ad0761b0f37a1e26286b0e977c69aa274524e79097a56dc4bd9e1b371c71c718b10",
+			"0"),
+	},
+	{
+		fp2Hex("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb",
+			"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb"),
+		fp2Hex("0",
+			"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa9d3"),
+		fp2Hex("12",
+			"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa99"),
+		fp2Hex("1", "0"),
+	},
+}
+
+func mapToG1(u *big.Int) affinePoint[*big.Int] {
+	f := fpField{}
+	x, y := sswu[*big.Int](f, u, g1IsoA, g1IsoB, g1IsoZ)
+	x, y, ok := isogeny[*big.Int](f, &g1Isogeny, x, y)
+	return affinePoint[*big.Int]{x: x, y: y, inf: !ok}
+}
+
+func mapToG2(u fp2) affinePoint[fp2] {
+	f := fp2Field{}
+	x, y := sswu[fp2](f, u, g2IsoA, g2IsoB, g2IsoZ)
+	x, y, ok := isogeny[fp2](f, &g2Isogeny, x, y)
+	return affinePoint[fp2]{x: x, y: y, inf: !ok}
+}
+
+// sswuHashToG1 is hash_to_curve for the BLS12381G1_XMD:SHA-256_SSWU_RO_ suite, RFC 9380 section 3.
+func sswuHashToG1(out *G1Point, msg, dst []byte) error {
+	u, err := hashToFps(msg, dst, 2, 1)
+	if err != nil {
+		return err
+	}
+	f := fpField{}
+	p := pointAdd[*big.Int](f, mapToG1(u[0][0]), mapToG1(u[1][0]))
+	p = pointMul[*big.Int](f, p, g1HEff)
+	if p.inf {
+		ClearG1(out)
+		return nil
+	}
+	var raw [G1UncompressedSize]byte
+	p.x.FillBytes(raw[:48])
+	p.y.FillBytes(raw[48:])
+	return g1FromUncompressedUnchecked(out, &raw)
+}
+
+// sswuHashToG2 is hash_to_curve for the BLS12381G2_XMD:SHA-256_SSWU_RO_ suite, RFC 9380 section 3.
+func sswuHashToG2(out *G2Point, msg, dst []byte) error {
+	u, err := hashToFps(msg, dst, 2, 2)
+	if err != nil {
+		return err
+	}
+	f := fp2Field{}
+	p := pointAdd[fp2](f, mapToG2(fp2{c0: u[0][0], c1: u[0][1]}), mapToG2(fp2{c0: u[1][0], c1: u[1][1]}))
+	p = pointMul[fp2](f, p, g2HEff)
+	if p.inf {
+		ClearG2(out)
+		return nil
+	}
+	var raw [G2UncompressedSize]byte
+	p.x.fillBytes(raw[:96])
+	p.y.fillBytes(raw[96:])
+	return g2FromUncompressedUnchecked(out, &raw)
+}
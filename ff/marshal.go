@@ -0,0 +1,175 @@
+package ff
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The marshalers encode Fr as 32 bytes big-endian, and points in the compressed ZCash format (see G1ToCompressed).
+// Text and JSON use the same bytes, as a 0x-prefixed hex string. Decoding checks the encoding is canonical,
+// and that points are in the prime order subgroup.
+
+func marshalHex(b []byte) []byte {
+	out := make([]byte, 2+hex.EncodedLen(len(b)))
+	copy(out, "0x")
+	hex.Encode(out[2:], b)
+	return out
+}
+
+func unmarshalHex(text []byte, size int) ([]byte, error) {
+	s := string(text)
+	if !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("hex value %q is missing the 0x prefix", s)
+	}
+	if len(s) != 2+2*size {
+		return nil, fmt.Errorf("expected %d hex characters, got %d", 2*size, len(s)-2)
+	}
+	return hex.DecodeString(s[2:])
+}
+
+func marshalJSONText(text []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+func unmarshalJSONText(data []byte, unmarshalText func(text []byte) error) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return unmarshalText([]byte(s))
+}
+
+func checkMarshalCurve() error {
+	if currentCurve != BLS12_381 {
+		return fmt.Errorf("%w, current curve is %s", ErrUnsupportedCurve, currentCurve)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the value as 32 bytes, big-endian.
+func (v Fr) MarshalBinary() ([]byte, error) {
+	return FrToBytes(&v, true), nil
+}
+
+// UnmarshalBinary decodes a canonical 32 byte big-endian value.
+func (v *Fr) UnmarshalBinary(data []byte) error {
+	out, err := FrFromBytesCanonical(data, true)
+	if err != nil {
+		return err
+	}
+	*v = out
+	return nil
+}
+
+// MarshalText encodes the value as 0x-prefixed hex, 32 bytes big-endian.
+func (v Fr) MarshalText() ([]byte, error) {
+	return marshalHex(FrToBytes(&v, true)), nil
+}
+
+// UnmarshalText decodes a 0x-prefixed hex value, 32 bytes big-endian, which must be less than r.
+func (v *Fr) UnmarshalText(text []byte) error {
+	b, err := unmarshalHex(text, FrSize)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalBinary(b)
+}
+
+// MarshalJSON encodes the value as a JSON string, see MarshalText.
+func (v Fr) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(v.MarshalText())
+}
+
+// UnmarshalJSON decodes a JSON string, see UnmarshalText.
+func (v *Fr) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, v.UnmarshalText)
+}
+
+// MarshalBinary encodes the point compressed, 48 bytes. Only BLS12-381 points can be encoded.
+func (p G1Point) MarshalBinary() ([]byte, error) {
+	if err := checkMarshalCurve(); err != nil {
+		return nil, err
+	}
+	out := G1ToCompressed(&p)
+	return out[:], nil
+}
+
+// UnmarshalBinary decodes a compressed point, see G1FromCompressed.
+func (p *G1Point) UnmarshalBinary(data []byte) error {
+	return G1FromCompressed(p, data)
+}
+
+// MarshalText encodes the compressed point as 0x-prefixed hex.
+func (p G1Point) MarshalText() ([]byte, error) {
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return marshalHex(b), nil
+}
+
+// UnmarshalText decodes a 0x-prefixed hex compressed point.
+func (p *G1Point) UnmarshalText(text []byte) error {
+	b, err := unmarshalHex(text, G1CompressedSize)
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalBinary(b)
+}
+
+// MarshalJSON encodes the point as a JSON string, see MarshalText.
+func (p G1Point) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(p.MarshalText())
+}
+
+// UnmarshalJSON decodes a JSON string, see UnmarshalText.
+func (p *G1Point) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, p.UnmarshalText)
+}
+
+// MarshalBinary encodes the point compressed, 96 bytes. Only BLS12-381 points can be encoded.
+func (p G2Point) MarshalBinary() ([]byte, error) {
+	if err := checkMarshalCurve(); err != nil {
+		return nil, err
+	}
+	out := G2ToCompressed(&p)
+	return out[:], nil
+}
+
+// UnmarshalBinary decodes a compressed point, see G2FromCompressed.
+func (p *G2Point) UnmarshalBinary(data []byte) error {
+	return G2FromCompressed(p, data)
+}
+
+// MarshalText encodes the compressed point as 0x-prefixed hex.
+func (p G2Point) MarshalText() ([]byte, error) {
+	b, err := p.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return marshalHex(b), nil
+}
+
+// UnmarshalText decodes a 0x-prefixed hex compressed point.
+func (p *G2Point) UnmarshalText(text []byte) error {
+	b, err := unmarshalHex(text, G2CompressedSize)
+	if err != nil {
+		return err
+	}
+	return p.UnmarshalBinary(b)
+}
+
+// MarshalJSON encodes the point as a JSON string, see MarshalText.
+func (p G2Point) MarshalJSON() ([]byte, error) {
+	return marshalJSONText(p.MarshalText())
+}
+
+// UnmarshalJSON decodes a JSON string, see UnmarshalText.
+func (p *G2Point) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONText(data, p.UnmarshalText)
+}
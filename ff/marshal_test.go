@@ -0,0 +1,132 @@
+package ff
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFrJSON(t *testing.T) {
+	poly := randomFrs(t, 3, 5)
+	CopyFr(&poly[0], &ZERO)
+	CopyFr(&poly[1], &MODULUS_MINUS1)
+	data, err := json.Marshal(poly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []Fr
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != len(poly) {
+		t.Fatalf("got %d values, expected %d", len(out), len(poly))
+	}
+	for i := range poly {
+		if !EqualFr(&out[i], &poly[i]) {
+			t.Fatalf("value %d: got %s, expected %s", i, FrStr(&out[i]), FrStr(&poly[i]))
+		}
+	}
+	if !strings.HasPrefix(string(data), `["0x0000000000000000000000000000000000000000000000000000000000000000",`) {
+		t.Fatalf("unexpected encoding: %s", data)
+	}
+
+	var v Fr
+	for _, bad := range []string{
+		`"0000000000000000000000000000000000000000000000000000000000000000"`,
+		`"0x00"`,
+		`"0xzz00000000000000000000000000000000000000000000000000000000000000"`,
+		`"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"`,
+		`1`,
+	} {
+		if err := json.Unmarshal([]byte(bad), &v); err == nil {
+			t.Errorf("expected error decoding %s", bad)
+		}
+	}
+}
+
+func TestFrText(t *testing.T) {
+	var v Fr
+	AsFr(&v, 258)
+	text, err := v.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "0x0000000000000000000000000000000000000000000000000000000000000102"; string(text) != expected {
+		t.Fatalf("got %s, expected %s", text, expected)
+	}
+	var out Fr
+	if err := out.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if !EqualFr(&out, &v) {
+		t.Fatalf("got %s, expected %s", FrStr(&out), FrStr(&v))
+	}
+}
+
+func TestPointsJSON(t *testing.T) {
+	type commitment struct {
+		Points []G1Point
+		Proof  G2Point
+		Zero   G1Point
+	}
+	in := commitment{Points: make([]G1Point, 3, 3), Zero: ZeroG1}
+	for i := range in.Points {
+		CopyG1(&in.Points[i], RandomG1())
+	}
+	MulG2(&in.Proof, &GenG2, RandomFr())
+	data, err := json.Marshal(&in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out commitment
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	for i := range in.Points {
+		if !EqualG1(&out.Points[i], &in.Points[i]) {
+			t.Fatalf("point %d: got %s, expected %s", i, StrG1(&out.Points[i]), StrG1(&in.Points[i]))
+		}
+	}
+	if !EqualG2(&out.Proof, &in.Proof) {
+		t.Fatalf("got %s, expected %s", StrG2(&out.Proof), StrG2(&in.Proof))
+	}
+	if !EqualG1(&out.Zero, &ZeroG1) {
+		t.Fatalf("got %s, expected the point at infinity", StrG1(&out.Zero))
+	}
+	var p G1Point
+	if err := json.Unmarshal([]byte(`"0x00"`), &p); err == nil {
+		t.Fatal("expected error decoding a short point")
+	}
+}
+
+func TestGob(t *testing.T) {
+	type setup struct {
+		Poly []Fr
+		G1   []G1Point
+		G2   G2Point
+	}
+	in := setup{Poly: randomFrs(t, 4, 4), G1: []G1Point{GenG1, ZeroG1}, G2: GenG2}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+	var out setup
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	for i := range in.Poly {
+		if !EqualFr(&out.Poly[i], &in.Poly[i]) {
+			t.Fatalf("value %d: got %s, expected %s", i, FrStr(&out.Poly[i]), FrStr(&in.Poly[i]))
+		}
+	}
+	for i := range in.G1 {
+		if !EqualG1(&out.G1[i], &in.G1[i]) {
+			t.Fatalf("point %d: got %s, expected %s", i, StrG1(&out.G1[i]), StrG1(&in.G1[i]))
+		}
+	}
+	if !EqualG2(&out.G2, &in.G2) {
+		t.Fatalf("got %s, expected %s", StrG2(&out.G2), StrG2(&in.G2))
+	}
+}
@@ -0,0 +1,72 @@
+package ff
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Below this many points per worker, the parallel multi-scalar multiplications do not split up the work further.
+const minMSMChunk = 1024
+
+// Fr scalars are encoded as 32 bytes, 256 bits, the top bit is always unused.
+const msmScalarBits = 256
+
+// pointOps is the group arithmetic of G1 or G2 the parallel multi-scalar multiplications and the fixed-base tables need.
+type pointOps[P any] struct {
+	clear   func(x *P)
+	add     func(dst *P, a *P, b *P)
+	linComb func(numbers []P, factors []Fr) *P
+}
+
+var g1Ops = pointOps[G1Point]{clear: ClearG1, add: AddG1, linComb: LinCombG1}
+var g2Ops = pointOps[G2Point]{clear: ClearG2, add: AddG2, linComb: LinCombG2}
+
+// msmDigit extracts the c bits of the big-endian scalar starting at bit offset (counting from the least significant bit).
+func msmDigit(scalar *[32]byte, offset uint, c uint) uint32 {
+	var out uint32
+	for i := uint(0); i < c && offset+i < msmScalarBits; i++ {
+		bit := offset + i
+		if (scalar[31-bit/8]>>(bit%8))&1 == 1 {
+			out |= 1 << i
+		}
+	}
+	return out
+}
+
+// parallelLinComb splits the points into chunks, runs the native multi-scalar multiplication of the backend
+// on each chunk concurrently, and sums the results.
+func parallelLinComb[P any](ops pointOps[P], points []P, factors []Fr, workers int) (*P, error) {
+	if len(points) != len(factors) {
+		return nil, fmt.Errorf("points length %d does not match factors length %d", len(points), len(factors))
+	}
+	var out P
+	ops.clear(&out)
+	if len(points) == 0 {
+		return &out, nil
+	}
+	var results []*P
+	var mu sync.Mutex
+	ParallelRange(len(points), workers, minMSMChunk, func(start, end int) {
+		res := ops.linComb(points[start:end], factors[start:end])
+		mu.Lock()
+		results = append(results, res)
+		mu.Unlock()
+	})
+	for _, res := range results {
+		ops.add(&out, &out, res)
+	}
+	return &out, nil
+}
+
+// ParallelLinCombG1 computes the multi-scalar multiplication of the numbers with the factors, like LinCombG1,
+// split into chunks that are computed concurrently with LinCombG1. If workers <= 0, runtime.NumCPU() workers are used.
+// Small inputs are computed on a single goroutine. Backends whose LinCombG1 is multi-threaded already,
+// like gnark-crypto, gain little from splitting up the work.
+func ParallelLinCombG1(numbers []G1Point, factors []Fr, workers int) (*G1Point, error) {
+	return parallelLinComb(g1Ops, numbers, factors, workers)
+}
+
+// ParallelLinCombG2 is like ParallelLinCombG1, for G2 points.
+func ParallelLinCombG2(numbers []G2Point, factors []Fr, workers int) (*G2Point, error) {
+	return parallelLinComb(g2Ops, numbers, factors, workers)
+}
@@ -0,0 +1,105 @@
+package ff
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParallelLinCombG1(t *testing.T) {
+	rng := NewSeededReader(16)
+	for _, n := range []int{0, 1, 2, 17, 300, 2*minMSMChunk + 5} {
+		points := make([]G1Point, n, n)
+		factors := make([]Fr, n, n)
+		for i := 0; i < n; i++ {
+			p, err := RandomG1From(rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			CopyG1(&points[i], p)
+			x, err := RandomFrFrom(rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			CopyFr(&factors[i], x)
+		}
+		if n > 3 {
+			// edge cases: zero, one and r-1 factors, and the point at infinity
+			CopyFr(&factors[0], &ZERO)
+			CopyFr(&factors[1], &ONE)
+			CopyFr(&factors[2], &MODULUS_MINUS1)
+			ClearG1(&points[3])
+		}
+		expected := LinCombG1(points, factors)
+		for _, workers := range []int{0, 1, 3} {
+			got, err := ParallelLinCombG1(points, factors, workers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !EqualG1(got, expected) {
+				t.Errorf("n %d, workers %d: got %s, expected %s", n, workers, StrG1(got), StrG1(expected))
+			}
+		}
+	}
+	if _, err := ParallelLinCombG1(make([]G1Point, 2), make([]Fr, 3), 0); err == nil {
+		t.Fatal("expected length mismatch error")
+	}
+}
+
+func TestParallelLinCombG2(t *testing.T) {
+	rng := NewSeededReader(17)
+	n := 33
+	points := make([]G2Point, n, n)
+	factors := make([]Fr, n, n)
+	var expected, tmp G2Point
+	ClearG2(&expected)
+	for i := 0; i < n; i++ {
+		x, err := RandomFrFrom(rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		MulG2(&points[i], &GenG2, x)
+		x, err = RandomFrFrom(rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		CopyFr(&factors[i], x)
+		MulG2(&tmp, &points[i], &factors[i])
+		AddG2(&expected, &expected, &tmp)
+	}
+	got, err := ParallelLinCombG2(points, factors, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !EqualG2(got, &expected) {
+		t.Fatalf("got %s, expected %s", StrG2(got), StrG2(&expected))
+	}
+	if got := LinCombG2(points, factors); !EqualG2(got, &expected) {
+		t.Fatalf("LinCombG2: got %s, expected %s", StrG2(got), StrG2(&expected))
+	}
+}
+
+func BenchmarkParallelLinCombG1(b *testing.B) {
+	for scale := uint8(8); scale <= 14; scale += 2 {
+		n := 1 << scale
+		points := make([]G1Point, n, n)
+		factors := make([]Fr, n, n)
+		for i := 0; i < n; i++ {
+			MulG1(&points[i], &GenG1, RandomFr())
+			CopyFr(&factors[i], RandomFr())
+		}
+		b.Run(fmt.Sprintf("LinCombG1/scale_%d", scale), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				LinCombG1(points, factors)
+			}
+		})
+		for _, workers := range []int{1, 0} {
+			b.Run(fmt.Sprintf("workers_%d/scale_%d", workers, scale), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := ParallelLinCombG1(points, factors, workers); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
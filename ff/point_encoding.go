@@ -0,0 +1,403 @@
+package ff
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Sizes of the encoded points, in the ZCash BLS12-381 serialization format
+// (https://github.com/zkcrypto/pairing/blob/master/src/bls12_381/README.md#serialization),
+// as used by the Ethereum consensus specs and the IETF BLS signature drafts.
+const (
+	G1CompressedSize   = 48
+	G1UncompressedSize = 96
+	G2CompressedSize   = 96
+	G2UncompressedSize = 192
+)
+
+// The three most significant bits of the first byte of an encoded point.
+const (
+	compressionFlag byte = 1 << 7
+	infinityFlag    byte = 1 << 6
+	sortFlag        byte = 1 << 5
+	flagsMask            = compressionFlag | infinityFlag | sortFlag
+)
+
+var (
+	ErrInvalidEncodingLength  = errors.New("invalid point encoding length")
+	ErrInvalidCompressionFlag = errors.New("invalid compression flag for the point encoding")
+	ErrInvalidInfinity        = errors.New("infinity flag set, but the point encoding is not all zero")
+	ErrInvalidSortFlag        = errors.New("sort flag set in an uncompressed encoding")
+	ErrNonCanonicalCoordinate = errors.New("point coordinate is not less than the base field modulus")
+	ErrPointNotOnCurve        = errors.New("point is not on the curve")
+	ErrPointNotInSubgroup     = errors.New("point is not in the prime order subgroup")
+	ErrUnsupportedCurve       = errors.New("point encoding is only defined for BLS12-381")
+)
+
+// The BLS12-381 base field modulus p.
+var fpModulus, _ = new(big.Int).SetString("1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab", 16)
+
+// (p-1)/2, coordinates larger than this have the sort flag set when compressed.
+var fpHalfModulus = new(big.Int).Rsh(fpModulus, 1)
+
+// G1ToCompressed encodes the point as the 48 byte x coordinate, with the flags set.
+func G1ToCompressed(p *G1Point) (out [G1CompressedSize]byte) {
+	raw := G1ToUncompressed(p)
+	if raw[0]&infinityFlag != 0 {
+		out[0] = compressionFlag | infinityFlag
+		return
+	}
+	copy(out[:], raw[:48])
+	out[0] |= compressionFlag
+	if fpLarger(new(big.Int).SetBytes(raw[48:96])) {
+		out[0] |= sortFlag
+	}
+	return
+}
+
+// G1ToUncompressed encodes the point as the 48 byte x and y coordinates, with the flags set.
+func G1ToUncompressed(p *G1Point) [G1UncompressedSize]byte {
+	checkEncodingCurve()
+	return g1ToUncompressed(p)
+}
+
+// G1FromCompressed decodes a 48 byte compressed point, and checks it is in the prime order subgroup.
+func G1FromCompressed(dst *G1Point, b []byte) error {
+	if err := checkFlags(b, G1CompressedSize, true); err != nil {
+		return err
+	}
+	if b[0]&infinityFlag != 0 {
+		ClearG1(dst)
+		return nil
+	}
+	sorted := b[0]&sortFlag != 0
+	b = stripFlags(b)
+	x, err := fpFromBytes(b[:48])
+	if err != nil {
+		return err
+	}
+	// y^2 = x^3 + 4
+	y2 := fpAdd(fpMul(fpMul(x, x), x), big.NewInt(4))
+	y := fpSqrt(y2)
+	if y == nil {
+		return ErrPointNotOnCurve
+	}
+	if fpLarger(y) != sorted {
+		y = fpNeg(y)
+	}
+	var raw [G1UncompressedSize]byte
+	x.FillBytes(raw[:48])
+	y.FillBytes(raw[48:])
+	return g1FromRaw(dst, &raw)
+}
+
+// G1FromUncompressed decodes a 96 byte uncompressed point, and checks it is on the curve,
+// and in the prime order subgroup.
+func G1FromUncompressed(dst *G1Point, b []byte) error {
+	if err := checkFlags(b, G1UncompressedSize, false); err != nil {
+		return err
+	}
+	if b[0]&infinityFlag != 0 {
+		ClearG1(dst)
+		return nil
+	}
+	b = stripFlags(b)
+	x, err := fpFromBytes(b[:48])
+	if err != nil {
+		return err
+	}
+	y, err := fpFromBytes(b[48:])
+	if err != nil {
+		return err
+	}
+	if fpMul(y, y).Cmp(fpAdd(fpMul(fpMul(x, x), x), big.NewInt(4))) != 0 {
+		return ErrPointNotOnCurve
+	}
+	var raw [G1UncompressedSize]byte
+	x.FillBytes(raw[:48])
+	y.FillBytes(raw[48:])
+	return g1FromRaw(dst, &raw)
+}
+
+func g1FromRaw(dst *G1Point, raw *[G1UncompressedSize]byte) error {
+	var p G1Point
+	if err := g1FromUncompressedUnchecked(&p, raw); err != nil {
+		return err
+	}
+	if !g1InSubgroup(&p) {
+		return ErrPointNotInSubgroup
+	}
+	CopyG1(dst, &p)
+	return nil
+}
+
+// G2ToCompressed encodes the point as the 96 byte x coordinate, with the flags set.
+// Coordinates in Fp2 are encoded as (c1, c0).
+func G2ToCompressed(p *G2Point) (out [G2CompressedSize]byte) {
+	raw := G2ToUncompressed(p)
+	if raw[0]&infinityFlag != 0 {
+		out[0] = compressionFlag | infinityFlag
+		return
+	}
+	copy(out[:], raw[:96])
+	out[0] |= compressionFlag
+	y := fp2{c1: new(big.Int).SetBytes(raw[96:144]), c0: new(big.Int).SetBytes(raw[144:192])}
+	if y.larger() {
+		out[0] |= sortFlag
+	}
+	return
+}
+
+// G2ToUncompressed encodes the point as the 96 byte x and y coordinates, with the flags set.
+// Coordinates in Fp2 are encoded as (c1, c0).
+func G2ToUncompressed(p *G2Point) [G2UncompressedSize]byte {
+	checkEncodingCurve()
+	return g2ToUncompressed(p)
+}
+
+// G2FromCompressed decodes a 96 byte compressed point, and checks it is in the prime order subgroup.
+func G2FromCompressed(dst *G2Point, b []byte) error {
+	if err := checkFlags(b, G2CompressedSize, true); err != nil {
+		return err
+	}
+	if b[0]&infinityFlag != 0 {
+		ClearG2(dst)
+		return nil
+	}
+	sorted := b[0]&sortFlag != 0
+	b = stripFlags(b)
+	x, err := fp2FromBytes(b[:96])
+	if err != nil {
+		return err
+	}
+	root := x.square().mul(x).add(g2B).sqrt()
+	if root == nil {
+		return ErrPointNotOnCurve
+	}
+	y := *root
+	if y.larger() != sorted {
+		y = y.neg()
+	}
+	var raw [G2UncompressedSize]byte
+	x.fillBytes(raw[:96])
+	y.fillBytes(raw[96:])
+	return g2FromRaw(dst, &raw)
+}
+
+// G2FromUncompressed decodes a 192 byte uncompressed point, and checks it is on the curve,
+// and in the prime order subgroup.
+func G2FromUncompressed(dst *G2Point, b []byte) error {
+	if err := checkFlags(b, G2UncompressedSize, false); err != nil {
+		return err
+	}
+	if b[0]&infinityFlag != 0 {
+		ClearG2(dst)
+		return nil
+	}
+	b = stripFlags(b)
+	x, err := fp2FromBytes(b[:96])
+	if err != nil {
+		return err
+	}
+	y, err := fp2FromBytes(b[96:])
+	if err != nil {
+		return err
+	}
+	if !y.square().equal(x.square().mul(x).add(g2B)) {
+		return ErrPointNotOnCurve
+	}
+	var raw [G2UncompressedSize]byte
+	x.fillBytes(raw[:96])
+	y.fillBytes(raw[96:])
+	return g2FromRaw(dst, &raw)
+}
+
+func g2FromRaw(dst *G2Point, raw *[G2UncompressedSize]byte) error {
+	var p G2Point
+	if err := g2FromUncompressedUnchecked(&p, raw); err != nil {
+		return err
+	}
+	if !g2InSubgroup(&p) {
+		return ErrPointNotInSubgroup
+	}
+	CopyG2(dst, &p)
+	return nil
+}
+
+func checkEncodingCurve() {
+	if currentCurve != BLS12_381 {
+		panic(fmt.Sprintf("%v, current curve is %s", ErrUnsupportedCurve, currentCurve))
+	}
+}
+
+// checkFlags validates the length and the flags of an encoded point, including the all-zero infinity encoding.
+func checkFlags(b []byte, size int, compressed bool) error {
+	if currentCurve != BLS12_381 {
+		return ErrUnsupportedCurve
+	}
+	if len(b) != size {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidEncodingLength, size, len(b))
+	}
+	if (b[0]&compressionFlag != 0) != compressed {
+		return ErrInvalidCompressionFlag
+	}
+	if !compressed && b[0]&sortFlag != 0 {
+		return ErrInvalidSortFlag
+	}
+	if b[0]&infinityFlag != 0 {
+		if b[0]&^(compressionFlag|infinityFlag) != 0 {
+			return ErrInvalidInfinity
+		}
+		for _, v := range b[1:] {
+			if v != 0 {
+				return ErrInvalidInfinity
+			}
+		}
+	}
+	return nil
+}
+
+// stripFlags returns a copy of the encoded point, with the flags cleared.
+func stripFlags(b []byte) []byte {
+	out := make([]byte, len(b), len(b))
+	copy(out, b)
+	out[0] &^= flagsMask
+	return out
+}
+
+// fpFromBytes decodes a 48 byte big-endian base field element.
+func fpFromBytes(b []byte) (*big.Int, error) {
+	v := new(big.Int).SetBytes(b[:48])
+	if v.Cmp(fpModulus) >= 0 {
+		return nil, ErrNonCanonicalCoordinate
+	}
+	return v, nil
+}
+
+func fpAdd(a, b *big.Int) *big.Int {
+	out := new(big.Int).Add(a, b)
+	return out.Mod(out, fpModulus)
+}
+
+func fpSub(a, b *big.Int) *big.Int {
+	out := new(big.Int).Sub(a, b)
+	return out.Mod(out, fpModulus)
+}
+
+func fpMul(a, b *big.Int) *big.Int {
+	out := new(big.Int).Mul(a, b)
+	return out.Mod(out, fpModulus)
+}
+
+func fpNeg(a *big.Int) *big.Int {
+	out := new(big.Int).Neg(a)
+	return out.Mod(out, fpModulus)
+}
+
+// fpSqrt returns a square root of a, or nil if there is none.
+func fpSqrt(a *big.Int) *big.Int {
+	out := new(big.Int).ModSqrt(a, fpModulus)
+	if out == nil {
+		return nil
+	}
+	return out
+}
+
+// fpLarger is the sort flag of a coordinate: whether it is larger than its negation.
+func fpLarger(a *big.Int) bool {
+	return a.Cmp(fpHalfModulus) > 0
+}
+
+// fp2 is an element c0 + c1*u of Fp2 = Fp[u]/(u^2+1), only used for (de)compression.
+type fp2 struct {
+	c0, c1 *big.Int
+}
+
+// The constant of the G2 curve equation y^2 = x^3 + 4(1+u)
+var g2B = fp2{c0: big.NewInt(4), c1: big.NewInt(4)}
+
+// fp2FromBytes decodes a 96 byte big-endian (c1, c0) element.
+func fp2FromBytes(b []byte) (fp2, error) {
+	c1, err := fpFromBytes(b[:48])
+	if err != nil {
+		return fp2{}, err
+	}
+	c0, err := fpFromBytes(b[48:96])
+	if err != nil {
+		return fp2{}, err
+	}
+	return fp2{c0: c0, c1: c1}, nil
+}
+
+func (a fp2) fillBytes(b []byte) {
+	a.c1.FillBytes(b[:48])
+	a.c0.FillBytes(b[48:96])
+}
+
+func (a fp2) add(b fp2) fp2 {
+	return fp2{c0: fpAdd(a.c0, b.c0), c1: fpAdd(a.c1, b.c1)}
+}
+
+func (a fp2) mul(b fp2) fp2 {
+	// (a0 + a1*u)(b0 + b1*u) = a0*b0 - a1*b1 + (a0*b1 + a1*b0)*u
+	return fp2{
+		c0: fpSub(fpMul(a.c0, b.c0), fpMul(a.c1, b.c1)),
+		c1: fpAdd(fpMul(a.c0, b.c1), fpMul(a.c1, b.c0)),
+	}
+}
+
+func (a fp2) square() fp2 {
+	return a.mul(a)
+}
+
+func (a fp2) neg() fp2 {
+	return fp2{c0: fpNeg(a.c0), c1: fpNeg(a.c1)}
+}
+
+func (a fp2) equal(b fp2) bool {
+	return a.c0.Cmp(b.c0) == 0 && a.c1.Cmp(b.c1) == 0
+}
+
+// larger is the sort flag of a coordinate, comparing c1 first, and c0 if c1 is zero.
+func (a fp2) larger() bool {
+	if a.c1.Sign() != 0 {
+		return fpLarger(a.c1)
+	}
+	return fpLarger(a.c0)
+}
+
+// sqrt returns a square root of a, or nil if there is none.
+func (a fp2) sqrt() *fp2 {
+	var out fp2
+	if a.c1.Sign() == 0 {
+		if r := fpSqrt(a.c0); r != nil {
+			out = fp2{c0: r, c1: new(big.Int)}
+		} else if r := fpSqrt(fpNeg(a.c0)); r != nil {
+			// sqrt(a0) = sqrt(-a0) * u, as u^2 = -1
+			out = fp2{c0: new(big.Int), c1: r}
+		} else {
+			return nil
+		}
+	} else {
+		// with the norm n = a0^2 + a1^2, and x0^2 = (a0 +- sqrt(n)) / 2, x1 = a1 / (2*x0)
+		n := fpSqrt(fpAdd(fpMul(a.c0, a.c0), fpMul(a.c1, a.c1)))
+		if n == nil {
+			return nil
+		}
+		inv2 := new(big.Int).ModInverse(big.NewInt(2), fpModulus)
+		x0 := fpSqrt(fpMul(fpAdd(a.c0, n), inv2))
+		if x0 == nil {
+			x0 = fpSqrt(fpMul(fpSub(a.c0, n), inv2))
+			if x0 == nil {
+				return nil
+			}
+		}
+		x1 := fpMul(a.c1, new(big.Int).ModInverse(fpMul(x0, big.NewInt(2)), fpModulus))
+		out = fp2{c0: x0, c1: x1}
+	}
+	if !out.square().equal(a) {
+		return nil
+	}
+	return &out
+}
@@ -0,0 +1,151 @@
+package ff
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// From the ZCash / Ethereum consensus specs.
+const (
+	genG1CompressedHex = "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+	genG2CompressedHex = "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e" +
+		"024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"
+)
+
+func TestG1Encoding(t *testing.T) {
+	gen := G1ToCompressed(&GenG1)
+	if got := hex.EncodeToString(gen[:]); got != genG1CompressedHex {
+		t.Fatalf("got generator encoding %s, expected %s", got, genG1CompressedHex)
+	}
+	var p, neg, got G1Point
+	MulG1(&p, &GenG1, RandomFr())
+	CopyG1(&neg, &p)
+	NegG1(&neg)
+	for _, point := range []*G1Point{&GenG1, &p, &neg, &ZeroG1} {
+		c := G1ToCompressed(point)
+		if err := G1FromCompressed(&got, c[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !EqualG1(&got, point) {
+			t.Fatalf("compressed roundtrip: got %s, expected %s", StrG1(&got), StrG1(point))
+		}
+		u := G1ToUncompressed(point)
+		if err := G1FromUncompressed(&got, u[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !EqualG1(&got, point) {
+			t.Fatalf("uncompressed roundtrip: got %s, expected %s", StrG1(&got), StrG1(point))
+		}
+	}
+	if c := G1ToCompressed(&ZeroG1); c[0] != 0xc0 {
+		t.Fatalf("bad infinity flags: %x", c[0])
+	}
+	if u := G1ToUncompressed(&ZeroG1); u[0] != 0x40 {
+		t.Fatalf("bad infinity flags: %x", u[0])
+	}
+
+	c := G1ToCompressed(&p)
+	u := G1ToUncompressed(&p)
+	expectErr := func(name string, err error, expected error) {
+		t.Helper()
+		if !errors.Is(err, expected) {
+			t.Errorf("%s: got error %v, expected %v", name, err, expected)
+		}
+	}
+	expectErr("short", G1FromCompressed(&got, c[:47]), ErrInvalidEncodingLength)
+	expectErr("uncompressed as compressed", G1FromCompressed(&got, u[:48]), ErrInvalidCompressionFlag)
+	badU := u
+	badU[0] |= compressionFlag
+	expectErr("compressed flag in uncompressed", G1FromUncompressed(&got, badU[:]), ErrInvalidCompressionFlag)
+	badU = u
+	badU[0] |= sortFlag
+	expectErr("sort flag in uncompressed", G1FromUncompressed(&got, badU[:]), ErrInvalidSortFlag)
+	badC := G1ToCompressed(&ZeroG1)
+	badC[47] = 1
+	expectErr("non-zero infinity", G1FromCompressed(&got, badC[:]), ErrInvalidInfinity)
+	badC = G1ToCompressed(&ZeroG1)
+	badC[0] |= sortFlag
+	expectErr("sorted infinity", G1FromCompressed(&got, badC[:]), ErrInvalidInfinity)
+
+	// x = p is not canonical
+	fpModulus.FillBytes(badC[:])
+	badC[0] |= compressionFlag
+	expectErr("non-canonical", G1FromCompressed(&got, badC[:]), ErrNonCanonicalCoordinate)
+	// the y coordinate with flag bits set is not canonical either
+	badU = u
+	badU[48] |= sortFlag
+	expectErr("non-canonical y", G1FromUncompressed(&got, badU[:]), ErrNonCanonicalCoordinate)
+
+	badU = u
+	badU[95] ^= 1
+	expectErr("not on curve", G1FromUncompressed(&got, badU[:]), ErrPointNotOnCurve)
+	for x := int64(1); ; x++ {
+		bx := big.NewInt(x)
+		y := fpSqrt(fpAdd(fpMul(fpMul(bx, bx), bx), big.NewInt(4)))
+		var enc [G1CompressedSize]byte
+		bx.FillBytes(enc[:])
+		enc[0] |= compressionFlag
+		if y == nil {
+			expectErr("compressed not on curve", G1FromCompressed(&got, enc[:]), ErrPointNotOnCurve)
+			continue
+		}
+		// the cofactor is large, a point found like this is practically never in the subgroup
+		expectErr("not in subgroup", G1FromCompressed(&got, enc[:]), ErrPointNotInSubgroup)
+		break
+	}
+}
+
+func TestG2Encoding(t *testing.T) {
+	gen := G2ToCompressed(&GenG2)
+	if got := hex.EncodeToString(gen[:]); got != genG2CompressedHex {
+		t.Fatalf("got generator encoding %s, expected %s", got, genG2CompressedHex)
+	}
+	var p, neg, got G2Point
+	MulG2(&p, &GenG2, RandomFr())
+	CopyG2(&neg, &p)
+	NegG2(&neg)
+	for _, point := range []*G2Point{&GenG2, &p, &neg, &ZeroG2} {
+		c := G2ToCompressed(point)
+		if err := G2FromCompressed(&got, c[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !EqualG2(&got, point) {
+			t.Fatalf("compressed roundtrip: got %s, expected %s", StrG2(&got), StrG2(point))
+		}
+		u := G2ToUncompressed(point)
+		if err := G2FromUncompressed(&got, u[:]); err != nil {
+			t.Fatal(err)
+		}
+		if !EqualG2(&got, point) {
+			t.Fatalf("uncompressed roundtrip: got %s, expected %s", StrG2(&got), StrG2(point))
+		}
+	}
+
+	u := G2ToUncompressed(&p)
+	if err := G2FromCompressed(&got, u[:96]); !errors.Is(err, ErrInvalidCompressionFlag) {
+		t.Errorf("got error %v, expected %v", err, ErrInvalidCompressionFlag)
+	}
+	badU := u
+	badU[191] ^= 1
+	if err := G2FromUncompressed(&got, badU[:]); !errors.Is(err, ErrPointNotOnCurve) {
+		t.Errorf("got error %v, expected %v", err, ErrPointNotOnCurve)
+	}
+	for x := int64(1); ; x++ {
+		bx := fp2{c0: big.NewInt(x), c1: big.NewInt(1)}
+		var enc [G2CompressedSize]byte
+		bx.fillBytes(enc[:])
+		enc[0] |= compressionFlag
+		if bx.square().mul(bx).add(g2B).sqrt() == nil {
+			if err := G2FromCompressed(&got, enc[:]); !errors.Is(err, ErrPointNotOnCurve) {
+				t.Errorf("got error %v, expected %v", err, ErrPointNotOnCurve)
+			}
+			continue
+		}
+		if err := G2FromCompressed(&got, enc[:]); !errors.Is(err, ErrPointNotInSubgroup) {
+			t.Errorf("got error %v, expected %v", err, ErrPointNotInSubgroup)
+		}
+		break
+	}
+}
@@ -0,0 +1,79 @@
+package ff
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"math/big"
+)
+
+// RandomFrFrom samples a uniformly random Fr from the reader, using wide reduction:
+// 64 bytes are read and reduced modulo r, leaving a negligible bias (less than 2^-256).
+func RandomFrFrom(r io.Reader) (*Fr, error) {
+	var buf [64]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, err
+	}
+	v := new(big.Int).SetBytes(buf[:])
+	v.Mod(v, &currentModulus)
+	var out Fr
+	frFromBig(&out, v)
+	return &out, nil
+}
+
+// RandomG1From returns a uniformly random G1 point, a multiple of GenG1 by a scalar from RandomFrFrom.
+func RandomG1From(r io.Reader) (*G1Point, error) {
+	x, err := RandomFrFrom(r)
+	if err != nil {
+		return nil, err
+	}
+	var out G1Point
+	MulG1(&out, &GenG1, x)
+	return &out, nil
+}
+
+// RandomG1 returns a random G1 point, using crypto/rand.
+func RandomG1() *G1Point {
+	out, err := RandomG1From(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+type seededReader struct {
+	seed    [8]byte
+	counter uint64
+	block   [sha256.Size]byte
+	// unread bytes of the current block
+	remaining []byte
+}
+
+// NewSeededReader returns a deterministic stream of pseudo-random bytes, the concatenation of
+// SHA-256(seed || counter) blocks, with 8-byte big-endian seed and counter.
+// The stream is the same on every platform and for every backend, so e.g. RandomFrFrom results
+// can be replayed from the seed in tests. It is not meant for generating secrets.
+func NewSeededReader(seed int64) io.Reader {
+	r := &seededReader{}
+	binary.BigEndian.PutUint64(r.seed[:], uint64(seed))
+	return r
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.remaining) == 0 {
+			var input [16]byte
+			copy(input[:8], r.seed[:])
+			binary.BigEndian.PutUint64(input[8:], r.counter)
+			r.counter++
+			r.block = sha256.Sum256(input[:])
+			r.remaining = r.block[:]
+		}
+		c := copy(p[n:], r.remaining)
+		r.remaining = r.remaining[c:]
+		n += c
+	}
+	return n, nil
+}
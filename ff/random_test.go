@@ -0,0 +1,72 @@
+package ff
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestSeededReader(t *testing.T) {
+	var a, b [100]byte
+	if _, err := io.ReadFull(NewSeededReader(42), a[:]); err != nil {
+		t.Fatal(err)
+	}
+	// read the same stream in odd sized chunks
+	r := NewSeededReader(42)
+	for i := 0; i < len(b); i += 7 {
+		end := Min(i+7, len(b))
+		if _, err := io.ReadFull(r, b[i:end]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if a != b {
+		t.Fatal("seeded reader output depends on the read sizes")
+	}
+	if _, err := io.ReadFull(NewSeededReader(43), b[:]); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(a[:], b[:]) {
+		t.Fatal("expected different seeds to give different streams")
+	}
+}
+
+func TestRandomFrFrom(t *testing.T) {
+	// the first value of seed 1, the same for all backends
+	x, err := RandomFrFrom(NewSeededReader(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := FrStr(x), "1468763298940867897327733633868654979607312329740375690253927616211493834745"; got != expected {
+		t.Fatalf("got %s, expected %s", got, expected)
+	}
+	r1, r2 := NewSeededReader(7), NewSeededReader(7)
+	for i := 0; i < 10; i++ {
+		a, err := RandomFrFrom(r1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := RandomFrFrom(r2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !EqualFr(a, b) {
+			t.Fatal("expected the same values for the same seed")
+		}
+	}
+	if _, err := RandomFrFrom(bytes.NewReader(make([]byte, 63))); err == nil {
+		t.Fatal("expected error for short reader")
+	}
+
+	p, err := RandomG1From(NewSeededReader(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected G1Point
+	MulG1(&expected, &GenG1, x)
+	if !EqualG1(p, &expected) {
+		t.Fatal("expected the G1 point to be the generator times the seeded scalar")
+	}
+	if EqualG1(RandomG1(), RandomG1()) {
+		t.Fatal("expected different random points")
+	}
+}
@@ -0,0 +1,94 @@
+package ff
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TwoAdicity returns the largest s such that 2^s divides modulus - 1,
+// i.e. the largest power of two for which the field has roots of unity.
+func TwoAdicity(modulus *big.Int) uint {
+	rMinus1 := new(big.Int).Sub(modulus, big.NewInt(1))
+	return rMinus1.TrailingZeroBits()
+}
+
+// RootOfUnityBig computes a primitive 2^k-th root of unity, pow(generator, (modulus - 1) / 2^k, modulus).
+// The generator must generate the multiplicative group of the field, an error is returned
+// if k exceeds the two-adicity of the field, or if the result does not have exact order 2^k.
+func RootOfUnityBig(modulus *big.Int, generator uint64, k uint) (*big.Int, error) {
+	if k > TwoAdicity(modulus) {
+		return nil, fmt.Errorf("field has no 2^%d-th roots of unity, two-adicity is %d", k, TwoAdicity(modulus))
+	}
+	rMinus1 := new(big.Int).Sub(modulus, big.NewInt(1))
+	e := new(big.Int).Rsh(rMinus1, k)
+	root := new(big.Int).Exp(new(big.Int).SetUint64(generator), e, modulus)
+	if k > 0 {
+		// root has order 2^k exactly if root^(2^(k-1)) = -1
+		half := new(big.Int).Exp(root, new(big.Int).Lsh(big.NewInt(1), k-1), modulus)
+		if half.Cmp(rMinus1) != 0 {
+			return nil, fmt.Errorf("%d is not a generator, its 2^%d-th root of unity does not have exact order", generator, k)
+		}
+	} else if root.Cmp(big.NewInt(1)) != 0 {
+		return nil, fmt.Errorf("%d does not have order dividing the modulus - 1", generator)
+	}
+	return root, nil
+}
+
+// Scale2RootsOfUnityBig computes the table of primitive 2^k-th roots of unity,
+// for k from 0 up to and including the two-adicity of the field.
+func Scale2RootsOfUnityBig(modulus *big.Int, generator uint64) ([]*big.Int, error) {
+	maxScale := TwoAdicity(modulus)
+	out := make([]*big.Int, maxScale+1, maxScale+1)
+	for k := uint(0); k <= maxScale; k++ {
+		root, err := RootOfUnityBig(modulus, generator, k)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = root
+	}
+	return out, nil
+}
+
+// RootOfUnity sets dst to the primitive 2^k-th root of unity of the current field,
+// derived from PRIMITIVE_ROOT.
+func RootOfUnity(dst *Fr, k uint) error {
+	root, err := RootOfUnityBig(&currentModulus, curves[currentCurve].primitiveRoot, k)
+	if err != nil {
+		return err
+	}
+	SetFr(dst, root.String())
+	return nil
+}
+
+// CheckScale2RootOfUnity validates a table of roots of unity like Scale2RootOfUnity:
+// entry k must have exact order 2^k, and be the square root of entry k+1.
+// The table must cover the full two-adicity of the current field.
+func CheckScale2RootOfUnity(roots []Fr) error {
+	if expected := TwoAdicity(&currentModulus) + 1; uint(len(roots)) != expected {
+		return fmt.Errorf("expected %d roots of unity, got %d", expected, len(roots))
+	}
+	var tmp Fr
+	for k := range roots {
+		if k+1 < len(roots) {
+			MulModFr(&tmp, &roots[k+1], &roots[k+1])
+			if !EqualFr(&tmp, &roots[k]) {
+				return fmt.Errorf("root of unity %d is not the square of root %d", k, k+1)
+			}
+		}
+		// square k-1 times, to get -1 if the order is exactly 2^k
+		CopyFr(&tmp, &roots[k])
+		if k == 0 {
+			if !EqualOne(&tmp) {
+				return fmt.Errorf("root of unity 0 is not 1: %s", FrStr(&tmp))
+			}
+			continue
+		}
+		for i := 0; i < k-1; i++ {
+			MulModFr(&tmp, &tmp, &tmp)
+		}
+		if !EqualFr(&tmp, &MODULUS_MINUS1) {
+			return fmt.Errorf("root of unity %d does not have exact order 2^%d", k, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ff
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestScale2RootsOfUnityBig(t *testing.T) {
+	// spot checks against the tables that were previously generated offline
+	expected := map[CurveID]map[uint]string{
+		BLS12_381: {
+			2:  "3465144826073652318776269530687742778270252468765361963008",
+			16: "46605497109352149548364111935960392432509601054990529243781317021485154656122",
+			28: "18727201054581607001749469507512963489976863652151448843860599973148080906836",
+			31: "43599901455287962219281063402626541872197057165786841304067502694013639882090",
+		},
+		BN254: {
+			2:  "21888242871839275217838484774961031246007050428528088939761107053157389710902",
+			16: "421743594562400382753388642386256516545992082196004333756405989743524594615",
+			28: "19103219067921713944291392827692070036145651957329286315305642004821462161904",
+		},
+	}
+	for c, values := range expected {
+		params := curves[c]
+		modulus, _ := new(big.Int).SetString(params.modulus, 10)
+		roots, err := Scale2RootsOfUnityBig(modulus, params.primitiveRoot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if uint(len(roots)) != TwoAdicity(modulus)+1 {
+			t.Fatalf("%s: got %d roots", c, len(roots))
+		}
+		for k, v := range values {
+			if got := roots[k].String(); got != v {
+				t.Errorf("%s: root %d: got %s, expected %s", c, k, got, v)
+			}
+		}
+		if _, err := RootOfUnityBig(modulus, params.primitiveRoot, TwoAdicity(modulus)+1); err == nil {
+			t.Errorf("%s: expected error for root beyond the two-adicity", c)
+		}
+	}
+	if got := TwoAdicity(big.NewInt(97)); got != 5 {
+		t.Fatalf("got two-adicity %d for 97, expected 5", got)
+	}
+	// 4 is a square, so not a generator
+	if _, err := RootOfUnityBig(big.NewInt(97), 4, 5); err == nil {
+		t.Fatal("expected error for non-generator")
+	}
+}
+
+func TestCheckScale2RootOfUnity(t *testing.T) {
+	if err := CheckScale2RootOfUnity(Scale2RootOfUnity); err != nil {
+		t.Fatal(err)
+	}
+	var root Fr
+	if err := RootOfUnity(&root, 10); err != nil {
+		t.Fatal(err)
+	}
+	if !EqualFr(&root, &Scale2RootOfUnity[10]) {
+		t.Fatalf("got %s, expected %s", FrStr(&root), FrStr(&Scale2RootOfUnity[10]))
+	}
+
+	corrupted := make([]Fr, len(Scale2RootOfUnity), len(Scale2RootOfUnity))
+	for i := range corrupted {
+		CopyFr(&corrupted[i], &Scale2RootOfUnity[i])
+	}
+	AddModFr(&corrupted[7], &corrupted[7], &ONE)
+	if err := CheckScale2RootOfUnity(corrupted); err == nil {
+		t.Fatal("expected corrupted table to fail the check")
+	}
+	if err := CheckScale2RootOfUnity(Scale2RootOfUnity[:10]); err == nil {
+		t.Fatal("expected truncated table to fail the check")
+	}
+}
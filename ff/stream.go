@@ -0,0 +1,232 @@
+package ff
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+// An Fr vector stream is a fixed size header, the elements, and a checksum trailer:
+//
+//	magic "FRVC" | version (1 byte) | curve (1 byte) | endianness (1 byte) | reserved (1 byte) | count (8 bytes, big-endian)
+//	count * 32 byte elements, canonical, in the endianness of the header
+//	CRC-32C of the elements (4 bytes, big-endian)
+//
+// Element i starts at byte FrStreamHeaderSize + i*FrSize, so a stream written to a file can be memory-mapped.
+const (
+	FrStreamHeaderSize  = 16
+	FrStreamTrailerSize = 4
+)
+
+const frStreamVersion = 1
+
+var frStreamMagic = [4]byte{'F', 'R', 'V', 'C'}
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+var (
+	ErrFrStreamMagic    = errors.New("not an Fr vector stream")
+	ErrFrStreamChecksum = errors.New("checksum mismatch in Fr vector stream")
+)
+
+// FrWriter writes a vector of a known number of Fr elements as a stream, without buffering the vector.
+type FrWriter struct {
+	w         *bufio.Writer
+	bigEndian bool
+	count     uint64
+	written   uint64
+	checksum  hash.Hash32
+}
+
+// NewFrWriter writes the stream header for count elements to w, the elements are encoded big-endian or little-endian.
+// The elements must be written with Write, and the stream completed with Close.
+func NewFrWriter(w io.Writer, count uint64, bigEndian bool) (*FrWriter, error) {
+	var header [FrStreamHeaderSize]byte
+	copy(header[:4], frStreamMagic[:])
+	header[4] = frStreamVersion
+	header[5] = byte(currentCurve)
+	if bigEndian {
+		header[6] = 1
+	}
+	binary.BigEndian.PutUint64(header[8:], count)
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(header[:]); err != nil {
+		return nil, err
+	}
+	return &FrWriter{w: bw, bigEndian: bigEndian, count: count, checksum: crc32.New(crc32c)}, nil
+}
+
+// Write appends the values to the stream. Writing more values than the count of the header is an error.
+func (fw *FrWriter) Write(values []Fr) error {
+	if uint64(len(values)) > fw.count-fw.written {
+		return fmt.Errorf("cannot write %d values, only %d of %d left", len(values), fw.count-fw.written, fw.count)
+	}
+	for i := range values {
+		var b [FrSize]byte
+		if fw.bigEndian {
+			b = FrTo32BE(&values[i])
+		} else {
+			b = FrTo32(&values[i])
+		}
+		if _, err := fw.w.Write(b[:]); err != nil {
+			return err
+		}
+		fw.checksum.Write(b[:])
+		fw.written++
+	}
+	return nil
+}
+
+// Close writes the checksum trailer, and flushes the stream. It does not close the underlying writer.
+// All values announced in the header must have been written.
+func (fw *FrWriter) Close() error {
+	if fw.written != fw.count {
+		return fmt.Errorf("wrote %d values, but the header announced %d", fw.written, fw.count)
+	}
+	var trailer [FrStreamTrailerSize]byte
+	binary.BigEndian.PutUint32(trailer[:], fw.checksum.Sum32())
+	if _, err := fw.w.Write(trailer[:]); err != nil {
+		return err
+	}
+	return fw.w.Flush()
+}
+
+// FrReader reads a vector of Fr elements from a stream written by FrWriter.
+type FrReader struct {
+	r         *bufio.Reader
+	bigEndian bool
+	count     uint64
+	read      uint64
+	checksum  hash.Hash32
+	verified  bool
+}
+
+// NewFrReader reads and validates the stream header. The stream must be for the current curve.
+func NewFrReader(r io.Reader) (*FrReader, error) {
+	br := bufio.NewReader(r)
+	var header [FrStreamHeaderSize]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read Fr vector stream header: %w", err)
+	}
+	if [4]byte{header[0], header[1], header[2], header[3]} != frStreamMagic {
+		return nil, ErrFrStreamMagic
+	}
+	if header[4] != frStreamVersion {
+		return nil, fmt.Errorf("unsupported Fr vector stream version %d", header[4])
+	}
+	if curve := CurveID(header[5]); curve != currentCurve {
+		return nil, fmt.Errorf("the Fr vector stream is for curve %s, current curve is %s", curve, currentCurve)
+	}
+	if header[6] > 1 || header[7] != 0 {
+		return nil, fmt.Errorf("invalid Fr vector stream header flags %x %x", header[6], header[7])
+	}
+	return &FrReader{
+		r:         br,
+		bigEndian: header[6] == 1,
+		count:     binary.BigEndian.Uint64(header[8:]),
+		checksum:  crc32.New(crc32c),
+	}, nil
+}
+
+// Count returns the number of elements in the stream.
+func (sr *FrReader) Count() uint64 {
+	return sr.count
+}
+
+// BigEndian returns the endianness of the encoded elements.
+func (sr *FrReader) BigEndian() bool {
+	return sr.bigEndian
+}
+
+// Read decodes up to len(dst) elements into dst, and returns the number of elements read.
+// After the last element the checksum is verified, and following reads return io.EOF.
+// Non-canonical elements are an error.
+func (sr *FrReader) Read(dst []Fr) (int, error) {
+	if sr.verified {
+		return 0, io.EOF
+	}
+	n := 0
+	var b [FrSize]byte
+	for n < len(dst) && sr.read < sr.count {
+		if _, err := io.ReadFull(sr.r, b[:]); err != nil {
+			return n, fmt.Errorf("failed to read element %d of %d: %w", sr.read, sr.count, err)
+		}
+		sr.checksum.Write(b[:])
+		v, err := FrFromBytesCanonical(b[:], sr.bigEndian)
+		if err != nil {
+			return n, fmt.Errorf("element %d: %w", sr.read, err)
+		}
+		CopyFr(&dst[n], &v)
+		n++
+		sr.read++
+	}
+	if sr.read == sr.count {
+		var trailer [FrStreamTrailerSize]byte
+		if _, err := io.ReadFull(sr.r, trailer[:]); err != nil {
+			return n, fmt.Errorf("failed to read Fr vector stream checksum: %w", err)
+		}
+		if binary.BigEndian.Uint32(trailer[:]) != sr.checksum.Sum32() {
+			return n, ErrFrStreamChecksum
+		}
+		sr.verified = true
+	}
+	return n, nil
+}
+
+// ReadInto reads all remaining elements into dst, which must have exactly the remaining number of elements,
+// e.g. allocated from Count if the source is trusted. The vector is held in memory only once.
+func (sr *FrReader) ReadInto(dst []Fr) error {
+	if remaining := sr.count - sr.read; uint64(len(dst)) != remaining {
+		return fmt.Errorf("cannot read %d remaining elements into %d", remaining, len(dst))
+	}
+	if _, err := sr.Read(dst); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// frStreamReadChunk is the number of elements ReadAll reads at a time, so a corrupt or hostile
+// count in the header cannot make it allocate more than the stream actually contains.
+const frStreamReadChunk = 1 << 12
+
+// ReadAll reads all remaining elements of the stream. The result grows as the elements arrive,
+// the count of the header is not trusted for the allocation. While the result grows, the old and the new
+// array are alive together, so ReadAll can use up to about twice the memory of the vector.
+// Use ReadInto to read into a preallocated vector instead.
+func (sr *FrReader) ReadAll() ([]Fr, error) {
+	out := make([]Fr, 0, 0)
+	for {
+		k := sr.count - sr.read
+		if k > frStreamReadChunk {
+			k = frStreamReadChunk
+		}
+		start := len(out)
+		if uint64(cap(out)-start) < k {
+			// double the capacity, but not beyond what the header announces
+			grow := uint64(cap(out))
+			if grow < k {
+				grow = k
+			}
+			if remaining := sr.count - sr.read; grow > remaining {
+				grow = remaining
+			}
+			grown := make([]Fr, start, uint64(start)+grow)
+			// the elements move to the new array, the old one is dropped
+			copy(grown, out)
+			out = grown
+		}
+		out = out[:uint64(start)+k]
+		n, err := sr.Read(out[start:])
+		out = out[:start+n]
+		if err == io.EOF || (err == nil && sr.verified) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
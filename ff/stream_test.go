@@ -0,0 +1,218 @@
+package ff
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func writeFrStream(t *testing.T, values []Fr, bigEndian bool) []byte {
+	var buf bytes.Buffer
+	w, err := NewFrWriter(&buf, uint64(len(values)), bigEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// write in uneven chunks
+	for start := 0; start < len(values); start += 7 {
+		if err := w.Write(values[start:Min(start+7, len(values))]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFrStream(t *testing.T) {
+	values := randomFrs(t, 5, 100)
+	CopyFr(&values[0], &MODULUS_MINUS1)
+	for _, bigEndian := range []bool{false, true} {
+		data := writeFrStream(t, values, bigEndian)
+		if expected := FrStreamHeaderSize + len(values)*FrSize + FrStreamTrailerSize; len(data) != expected {
+			t.Fatalf("got %d bytes, expected %d", len(data), expected)
+		}
+		// element i is at a fixed offset
+		elem, err := FrFromBytesCanonical(data[FrStreamHeaderSize+3*FrSize:FrStreamHeaderSize+4*FrSize], bigEndian)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !EqualFr(&elem, &values[3]) {
+			t.Fatalf("element 3: got %s, expected %s", FrStr(&elem), FrStr(&values[3]))
+		}
+
+		r, err := NewFrReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.Count() != uint64(len(values)) || r.BigEndian() != bigEndian {
+			t.Fatalf("unexpected header: count %d, big-endian %v", r.Count(), r.BigEndian())
+		}
+		var out []Fr
+		buf := make([]Fr, 13, 13)
+		for {
+			n, err := r.Read(buf)
+			out = append(out, buf[:n]...)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(out) != len(values) {
+			t.Fatalf("got %d values, expected %d", len(out), len(values))
+		}
+		for i := range values {
+			if !EqualFr(&out[i], &values[i]) {
+				t.Fatalf("value %d: got %s, expected %s", i, FrStr(&out[i]), FrStr(&values[i]))
+			}
+		}
+	}
+}
+
+func TestFrStreamEmpty(t *testing.T) {
+	data := writeFrStream(t, nil, false)
+	r, err := NewFrReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 0 {
+		t.Fatalf("expected no values, got %d", len(out))
+	}
+}
+
+func TestFrStreamReadAllChunks(t *testing.T) {
+	values := randomFrs(t, 7, 2*frStreamReadChunk+3)
+	r, err := NewFrReader(bytes.NewReader(writeFrStream(t, values, false)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != len(values) {
+		t.Fatalf("got %d values, expected %d", len(out), len(values))
+	}
+	for i := range values {
+		if !EqualFr(&out[i], &values[i]) {
+			t.Fatalf("value %d: got %s, expected %s", i, FrStr(&out[i]), FrStr(&values[i]))
+		}
+	}
+}
+
+func TestFrStreamReadInto(t *testing.T) {
+	values := randomFrs(t, 8, 50)
+	data := writeFrStream(t, values, true)
+	r, err := NewFrReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ReadInto(make([]Fr, 49, 49)); err == nil {
+		t.Fatal("expected error for a destination of the wrong length")
+	}
+	first := make([]Fr, 10, 10)
+	if _, err := r.Read(first); err != nil {
+		t.Fatal(err)
+	}
+	rest := make([]Fr, r.Count()-10, r.Count()-10)
+	if err := r.ReadInto(rest); err != nil {
+		t.Fatal(err)
+	}
+	out := append(first, rest...)
+	for i := range values {
+		if !EqualFr(&out[i], &values[i]) {
+			t.Fatalf("value %d: got %s, expected %s", i, FrStr(&out[i]), FrStr(&values[i]))
+		}
+	}
+	if err := r.ReadInto(nil); err != nil {
+		t.Fatalf("expected nothing left to read, got %v", err)
+	}
+
+	r, err = NewFrReader(bytes.NewReader(data[:len(data)-FrSize]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ReadInto(make([]Fr, r.Count(), r.Count())); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected truncation error, got %v", err)
+	}
+}
+
+func TestFrStreamErrors(t *testing.T) {
+	values := randomFrs(t, 6, 10)
+	data := writeFrStream(t, values, true)
+
+	corrupt := append([]byte(nil), data...)
+	corrupt[FrStreamHeaderSize+FrSize-1] ^= 1
+	r, err := NewFrReader(bytes.NewReader(corrupt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReadAll(); !errors.Is(err, ErrFrStreamChecksum) {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+
+	nonCanonical := append([]byte(nil), data...)
+	for i := 0; i < FrSize; i++ {
+		nonCanonical[FrStreamHeaderSize+i] = 0xff
+	}
+	r, err = NewFrReader(bytes.NewReader(nonCanonical))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReadAll(); err == nil {
+		t.Fatal("expected non-canonical element error")
+	}
+
+	r, err = NewFrReader(bytes.NewReader(data[:len(data)-FrSize]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReadAll(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected truncation error, got %v", err)
+	}
+
+	// a header announcing far more elements than the stream holds must not be allocated up front
+	oversized := append([]byte(nil), data...)
+	binary.BigEndian.PutUint64(oversized[8:FrStreamHeaderSize], 1<<62)
+	r, err = NewFrReader(bytes.NewReader(oversized))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReadAll(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("expected truncation error for oversized count, got %v", err)
+	}
+
+	badMagic := append([]byte(nil), data...)
+	badMagic[0] = 'X'
+	if _, err := NewFrReader(bytes.NewReader(badMagic)); !errors.Is(err, ErrFrStreamMagic) {
+		t.Fatalf("expected magic error, got %v", err)
+	}
+	otherCurve := append([]byte(nil), data...)
+	otherCurve[5] = byte(BN254)
+	if _, err := NewFrReader(bytes.NewReader(otherCurve)); err == nil {
+		t.Fatal("expected curve mismatch error")
+	}
+
+	var buf bytes.Buffer
+	w, err := NewFrWriter(&buf, 2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(values[:3]); err == nil {
+		t.Fatal("expected error writing more values than announced")
+	}
+	if err := w.Write(values[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err == nil {
+		t.Fatal("expected error closing before all values are written")
+	}
+}
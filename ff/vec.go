@@ -0,0 +1,167 @@
+package ff
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Below this many elements per worker, the parallel vector operations do not split up the work further.
+const minVecChunk = 1024
+
+func checkVecLengths(dst []Fr, a []Fr, b []Fr) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("vector length %d does not match vector length %d", len(a), len(b))
+	}
+	if len(dst) != len(a) {
+		return fmt.Errorf("dst length %d does not match vector length %d", len(dst), len(a))
+	}
+	return nil
+}
+
+// ParallelRange splits [0, n) into at most workers ranges of at least minChunk elements each, calls fn on them
+// concurrently, and waits for them to complete. If workers <= 0, runtime.NumCPU() workers are used.
+// If the range is not split up, fn runs once on the calling goroutine, also for n = 0.
+func ParallelRange(n int, workers int, minChunk int, fn func(start, end int)) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if minChunk < 1 {
+		minChunk = 1
+	}
+	if maxWorkers := n / minChunk; workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if workers <= 1 {
+		fn(0, n)
+		return
+	}
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunk {
+		end := Min(start+chunk, n)
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// AddVecFr sets dst[i] = a[i] + b[i]. All slices must have the same length, dst may alias a or b.
+func AddVecFr(dst []Fr, a []Fr, b []Fr) error {
+	return ParallelAddVecFr(dst, a, b, 1)
+}
+
+// ParallelAddVecFr is like AddVecFr, split up over workers goroutines (runtime.NumCPU() if workers <= 0).
+func ParallelAddVecFr(dst []Fr, a []Fr, b []Fr, workers int) error {
+	if err := checkVecLengths(dst, a, b); err != nil {
+		return err
+	}
+	ParallelRange(len(dst), workers, minVecChunk, func(start, end int) {
+		for i := start; i < end; i++ {
+			AddModFr(&dst[i], &a[i], &b[i])
+		}
+	})
+	return nil
+}
+
+// SubVecFr sets dst[i] = a[i] - b[i]. All slices must have the same length, dst may alias a or b.
+func SubVecFr(dst []Fr, a []Fr, b []Fr) error {
+	return ParallelSubVecFr(dst, a, b, 1)
+}
+
+// ParallelSubVecFr is like SubVecFr, split up over workers goroutines (runtime.NumCPU() if workers <= 0).
+func ParallelSubVecFr(dst []Fr, a []Fr, b []Fr, workers int) error {
+	if err := checkVecLengths(dst, a, b); err != nil {
+		return err
+	}
+	ParallelRange(len(dst), workers, minVecChunk, func(start, end int) {
+		for i := start; i < end; i++ {
+			SubModFr(&dst[i], &a[i], &b[i])
+		}
+	})
+	return nil
+}
+
+// HadamardVecFr sets dst[i] = a[i] * b[i], the element-wise product. Unlike MulVecFr it writes into dst,
+// and returns an error on a length mismatch. All slices must have the same length, dst may alias a or b.
+func HadamardVecFr(dst []Fr, a []Fr, b []Fr) error {
+	return ParallelHadamardVecFr(dst, a, b, 1)
+}
+
+// ParallelHadamardVecFr is like HadamardVecFr, split up over workers goroutines (runtime.NumCPU() if workers <= 0).
+func ParallelHadamardVecFr(dst []Fr, a []Fr, b []Fr, workers int) error {
+	if err := checkVecLengths(dst, a, b); err != nil {
+		return err
+	}
+	ParallelRange(len(dst), workers, minVecChunk, func(start, end int) {
+		for i := start; i < end; i++ {
+			MulModFr(&dst[i], &a[i], &b[i])
+		}
+	})
+	return nil
+}
+
+// ScaleVecFr sets dst[i] = a[i] * s. dst and a must have the same length, and may be the same slice.
+func ScaleVecFr(dst []Fr, a []Fr, s *Fr) error {
+	return ParallelScaleVecFr(dst, a, s, 1)
+}
+
+// ParallelScaleVecFr is like ScaleVecFr, split up over workers goroutines (runtime.NumCPU() if workers <= 0).
+func ParallelScaleVecFr(dst []Fr, a []Fr, s *Fr, workers int) error {
+	if len(dst) != len(a) {
+		return fmt.Errorf("dst length %d does not match vector length %d", len(dst), len(a))
+	}
+	var scalar Fr
+	CopyFr(&scalar, s) // s may point into dst
+	ParallelRange(len(dst), workers, minVecChunk, func(start, end int) {
+		for i := start; i < end; i++ {
+			MulModFr(&dst[i], &a[i], &scalar)
+		}
+	})
+	return nil
+}
+
+// InnerProductFr sets dst to the sum of a[i] * b[i]. The slices must have the same length,
+// the inner product of empty slices is zero.
+func InnerProductFr(dst *Fr, a []Fr, b []Fr) error {
+	return ParallelInnerProductFr(dst, a, b, 1)
+}
+
+// ParallelInnerProductFr is like InnerProductFr, split up over workers goroutines (runtime.NumCPU() if workers <= 0).
+func ParallelInnerProductFr(dst *Fr, a []Fr, b []Fr, workers int) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("vector length %d does not match vector length %d", len(a), len(b))
+	}
+	var lock sync.Mutex
+	var sum Fr
+	CopyFr(&sum, &ZERO)
+	ParallelRange(len(a), workers, minVecChunk, func(start, end int) {
+		var partial, tmp Fr
+		CopyFr(&partial, &ZERO)
+		for i := start; i < end; i++ {
+			MulModFr(&tmp, &a[i], &b[i])
+			AddModFr(&partial, &partial, &tmp)
+		}
+		lock.Lock()
+		AddModFr(&sum, &sum, &partial)
+		lock.Unlock()
+	})
+	CopyFr(dst, &sum)
+	return nil
+}
+
+// PowersOfFr returns [1, x, x^2, ..., x^(n-1)].
+func PowersOfFr(x *Fr, n int) []Fr {
+	if n <= 0 {
+		return []Fr{}
+	}
+	out := make([]Fr, n, n)
+	CopyFr(&out[0], &ONE)
+	for i := 1; i < n; i++ {
+		MulModFr(&out[i], &out[i-1], x)
+	}
+	return out
+}
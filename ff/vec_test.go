@@ -0,0 +1,151 @@
+package ff
+
+import (
+	"sync"
+	"testing"
+)
+
+func randomFrs(t *testing.T, seed int64, n int) []Fr {
+	rng := NewSeededReader(seed)
+	out := make([]Fr, n, n)
+	for i := range out {
+		x, err := RandomFrFrom(rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		CopyFr(&out[i], x)
+	}
+	return out
+}
+
+func TestParallelRange(t *testing.T) {
+	for _, c := range []struct {
+		n, workers, minChunk int
+		maxCalls             int
+	}{{0, 4, 1, 1}, {1, 4, 1, 1}, {10, 1, 1, 1}, {10, 4, 1, 4}, {10, 4, 5, 2}, {100, 3, 10, 3}, {100, 8, 40, 2}, {7, 0, 1, 7}} {
+		seen := make([]int, c.n, c.n)
+		calls := 0
+		var mu sync.Mutex
+		ParallelRange(c.n, c.workers, c.minChunk, func(start, end int) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			for i := start; i < end; i++ {
+				seen[i]++
+			}
+		})
+		if calls < 1 || calls > c.maxCalls {
+			t.Errorf("n %d, workers %d, min chunk %d: got %d calls, expected 1 to %d", c.n, c.workers, c.minChunk, calls, c.maxCalls)
+		}
+		for i, v := range seen {
+			if v != 1 {
+				t.Fatalf("n %d, workers %d, min chunk %d: index %d visited %d times", c.n, c.workers, c.minChunk, i, v)
+			}
+		}
+	}
+}
+
+func TestVecFr(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 3*minVecChunk + 1} {
+		a := randomFrs(t, 1, n)
+		b := randomFrs(t, 2, n)
+		s := RandomFr()
+		var expectedInner Fr
+		CopyFr(&expectedInner, &ZERO)
+		expectedAdd := make([]Fr, n, n)
+		expectedSub := make([]Fr, n, n)
+		expectedScale := make([]Fr, n, n)
+		expectedMul := make([]Fr, n, n)
+		for i := 0; i < n; i++ {
+			AddModFr(&expectedAdd[i], &a[i], &b[i])
+			SubModFr(&expectedSub[i], &a[i], &b[i])
+			MulModFr(&expectedScale[i], &a[i], s)
+			MulModFr(&expectedMul[i], &a[i], &b[i])
+			AddModFr(&expectedInner, &expectedInner, &expectedMul[i])
+		}
+		check := func(name string, got []Fr, expected []Fr) {
+			for i := 0; i < n; i++ {
+				if !EqualFr(&got[i], &expected[i]) {
+					t.Fatalf("%s n %d: element %d differs, got %s, expected %s", name, n, i, FrStr(&got[i]), FrStr(&expected[i]))
+				}
+			}
+		}
+		check("mul", MulVecFr(a, b), expectedMul)
+		for _, workers := range []int{1, 0, 4} {
+			dst := make([]Fr, n, n)
+			if err := ParallelAddVecFr(dst, a, b, workers); err != nil {
+				t.Fatal(err)
+			}
+			check("add", dst, expectedAdd)
+			if err := ParallelSubVecFr(dst, a, b, workers); err != nil {
+				t.Fatal(err)
+			}
+			check("sub", dst, expectedSub)
+			if err := ParallelHadamardVecFr(dst, a, b, workers); err != nil {
+				t.Fatal(err)
+			}
+			check("hadamard", dst, expectedMul)
+			if err := ParallelScaleVecFr(dst, a, s, workers); err != nil {
+				t.Fatal(err)
+			}
+			check("scale", dst, expectedScale)
+			var inner Fr
+			if err := ParallelInnerProductFr(&inner, a, b, workers); err != nil {
+				t.Fatal(err)
+			}
+			if !EqualFr(&inner, &expectedInner) {
+				t.Fatalf("inner product n %d: got %s, expected %s", n, FrStr(&inner), FrStr(&expectedInner))
+			}
+		}
+		// in-place, dst aliases a
+		inPlace := append([]Fr(nil), a...)
+		if err := AddVecFr(inPlace, inPlace, b); err != nil {
+			t.Fatal(err)
+		}
+		check("add in-place", inPlace, expectedAdd)
+	}
+}
+
+func TestVecFrLengthMismatch(t *testing.T) {
+	a := make([]Fr, 3, 3)
+	b := make([]Fr, 4, 4)
+	if err := AddVecFr(a, a, b); err == nil {
+		t.Error("expected AddVecFr error")
+	}
+	if err := SubVecFr(b, a, a); err == nil {
+		t.Error("expected SubVecFr error on dst length")
+	}
+	if err := HadamardVecFr(a, a, b); err == nil {
+		t.Error("expected HadamardVecFr error")
+	}
+	if err := ScaleVecFr(b, a, &ONE); err == nil {
+		t.Error("expected ScaleVecFr error")
+	}
+	var dst Fr
+	if err := InnerProductFr(&dst, a, b); err == nil {
+		t.Error("expected InnerProductFr error")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected MulVecFr to panic")
+			}
+		}()
+		MulVecFr(a, b)
+	}()
+}
+
+func TestPowersOfFr(t *testing.T) {
+	x := RandomFr()
+	powers := PowersOfFr(x, 20)
+	var expected Fr
+	for i := range powers {
+		ExpModFrUint64(&expected, x, uint64(i))
+		if !EqualFr(&powers[i], &expected) {
+			t.Fatalf("power %d: got %s, expected %s", i, FrStr(&powers[i]), FrStr(&expected))
+		}
+	}
+	if len(PowersOfFr(x, 0)) != 0 {
+		t.Fatal("expected no powers")
+	}
+}
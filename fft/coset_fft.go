@@ -0,0 +1,147 @@
+package fft
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+// maxCachedCosetShifts bounds the number of shifts whose powers are cached on the settings,
+// the powers of any further shift are computed on every call.
+const maxCachedCosetShifts = 8
+
+// cosetPowers holds shift^i and shift^(-i), for i in [0, len(powers)).
+// The slices are not modified once cached, extending them allocates new ones.
+type cosetPowers struct {
+	shift     ff.Fr
+	invShift  ff.Fr
+	powers    []ff.Fr
+	invPowers []ff.Fr
+}
+
+// cosetCache caches the powers of each coset shift, keyed by the encoded shift.
+// The lock only guards the map, the powers are computed without holding it.
+type cosetCache struct {
+	lock   sync.Mutex
+	shifts map[[32]byte]*cosetPowers
+}
+
+func newCosetCache() *cosetCache {
+	return &cosetCache{shifts: make(map[[32]byte]*cosetPowers)}
+}
+
+func computeCosetPowers(b ff.Backend, shift *ff.Fr, n uint64) *cosetPowers {
+	var p cosetPowers
+	ff.CopyFr(&p.shift, shift)
+	b.InvModFr(&p.invShift, shift)
+	return p.extend(b, n)
+}
+
+// extendPowers returns the first n powers of x, reusing the given first powers.
+func extendPowers(b ff.Backend, powers []ff.Fr, x *ff.Fr, n uint64) []ff.Fr {
+	out := make([]ff.Fr, n, n)
+	for i := range powers {
+		ff.CopyFr(&out[i], &powers[i])
+	}
+	for i := uint64(len(powers)); i < n; i++ {
+		if i == 0 {
+			ff.CopyFr(&out[i], &ff.ONE)
+		} else {
+			b.MulModFr(&out[i], &out[i-1], x)
+		}
+	}
+	return out
+}
+
+// extend returns the first n powers, n must be at least the number of powers already computed.
+func (p *cosetPowers) extend(b ff.Backend, n uint64) *cosetPowers {
+	out := &cosetPowers{
+		powers:    extendPowers(b, p.powers, &p.shift, n),
+		invPowers: extendPowers(b, p.invPowers, &p.invShift, n),
+	}
+	ff.CopyFr(&out.shift, &p.shift)
+	ff.CopyFr(&out.invShift, &p.invShift)
+	return out
+}
+
+// shiftPowers returns the first n powers of the shift and its inverse. The powers of up to maxCachedCosetShifts
+// shifts are cached on the settings, and extended on demand, up to MaxWidth powers.
+func (fs *FFTSettings) shiftPowers(shift *ff.Fr, n uint64) (*cosetPowers, error) {
+	if ff.EqualZero(shift) {
+		return nil, fmt.Errorf("coset shift must not be zero")
+	}
+	b := fs.backend()
+	if fs.cosets == nil || n > fs.MaxWidth {
+		return computeCosetPowers(b, shift, n), nil
+	}
+	key := ff.FrTo32(shift)
+	fs.cosets.lock.Lock()
+	p, ok := fs.cosets.shifts[key]
+	full := len(fs.cosets.shifts) >= maxCachedCosetShifts
+	fs.cosets.lock.Unlock()
+	if ok && uint64(len(p.powers)) >= n {
+		return &cosetPowers{powers: p.powers[:n], invPowers: p.invPowers[:n]}, nil
+	}
+	if !ok && full {
+		return computeCosetPowers(b, shift, n), nil
+	}
+	// double the cached powers, so growing them one call at a time stays linear
+	target := n
+	if ok && uint64(2*len(p.powers)) > target {
+		target = uint64(2 * len(p.powers))
+	}
+	if target > fs.MaxWidth {
+		target = fs.MaxWidth
+	}
+	if ok {
+		p = p.extend(b, target)
+	} else {
+		p = computeCosetPowers(b, shift, target)
+	}
+	fs.cosets.lock.Lock()
+	// another goroutine may have cached the powers meanwhile, keep the longest
+	if cur, ok := fs.cosets.shifts[key]; ok && len(cur.powers) >= len(p.powers) {
+		p = cur
+	} else if ok || len(fs.cosets.shifts) < maxCachedCosetShifts {
+		fs.cosets.shifts[key] = p
+	}
+	fs.cosets.lock.Unlock()
+	return &cosetPowers{powers: p.powers[:n], invPowers: p.invPowers[:n]}, nil
+}
+
+// CosetFFT evaluates the polynomial with the given coefficients on the coset shift*H, where H is the group
+// of the len(vals)-th roots of unity: out[i] = p(shift * w^i). The inverse interpolates evaluations on the coset
+// back to coefficients. Like FFT, the values are padded with zeros to a power of two.
+// The shift should not be in H, e.g. ff.PRIMITIVE_ROOT or another multiplicative group generator, so the coset
+// is disjoint from H. The powers of the first few shifts are cached on the settings.
+func (fs *FFTSettings) CosetFFT(vals []ff.Fr, shift *ff.Fr, inv bool) ([]ff.Fr, error) {
+	n := uint64(len(vals))
+	if n > fs.MaxWidth {
+		return nil, fmt.Errorf("got %d values but only have %d roots of unity", n, fs.MaxWidth)
+	}
+	n = nextPowOf2(n)
+	p, err := fs.shiftPowers(shift, n)
+	if err != nil {
+		return nil, err
+	}
+	valsCopy := make([]ff.Fr, n, n)
+	for i := uint64(len(vals)); i < n; i++ {
+		ff.CopyFr(&valsCopy[i], &ff.ZERO)
+	}
+	if inv {
+		for i := range vals {
+			ff.CopyFr(&valsCopy[i], &vals[i])
+		}
+		out, err := fs.fftOwned(valsCopy, true)
+		if err != nil {
+			return nil, err
+		}
+		// p(shift * x) has coefficients c_i * shift^i, undo the shift
+		fs.scaleByPowers(out, out, p.invPowers)
+		return out, nil
+	}
+	// the evaluations of p(x) on shift*H are the evaluations of p(shift * x) on H
+	fs.scaleByPowers(valsCopy[:len(vals)], vals, p.powers)
+	return fs.fftOwned(valsCopy, false)
+}
@@ -0,0 +1,161 @@
+package fft
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+func TestCosetFFT(t *testing.T) {
+	fs := NewFFTSettings(5)
+	var shift2 ff.Fr
+	ff.AsFr(&shift2, 7)
+	for _, shift := range []*ff.Fr{&ff.PRIMITIVE_ROOT, &shift2} {
+		for _, n := range []int{1, 5, 16, 32} {
+			coeffs := make([]ff.Fr, n, n)
+			for i := range coeffs {
+				ff.CopyFr(&coeffs[i], ff.RandomFr())
+			}
+			evals, err := fs.CosetFFT(coeffs, shift, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			width := nextPowOf2(uint64(n))
+			if uint64(len(evals)) != width {
+				t.Fatalf("got %d evaluations, expected %d", len(evals), width)
+			}
+			// out[i] = p(shift * w^i)
+			stride := fs.MaxWidth / width
+			var x, expected ff.Fr
+			for i := range evals {
+				ff.MulModFr(&x, shift, &fs.ExpandedRootsOfUnity[uint64(i)*stride])
+				ff.EvalPolyAt(&expected, coeffs, &x)
+				if !ff.EqualFr(&evals[i], &expected) {
+					t.Fatalf("n %d: evaluation %d differs: got %s, expected %s", n, i, ff.FrStr(&evals[i]), ff.FrStr(&expected))
+				}
+			}
+			back, err := fs.CosetFFT(evals, shift, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range back {
+				expected := &ff.ZERO
+				if i < n {
+					expected = &coeffs[i]
+				}
+				if !ff.EqualFr(&back[i], expected) {
+					t.Fatalf("n %d: coefficient %d differs: got %s, expected %s", n, i, ff.FrStr(&back[i]), ff.FrStr(expected))
+				}
+			}
+		}
+	}
+	if len(fs.cosets.shifts) != 2 {
+		t.Fatalf("expected the powers of 2 shifts to be cached, got %d", len(fs.cosets.shifts))
+	}
+	if _, err := fs.CosetFFT(make([]ff.Fr, 4), &ff.ZERO, false); err == nil {
+		t.Fatal("expected error for a zero shift")
+	}
+	if _, err := fs.CosetFFT(make([]ff.Fr, 64), &shift2, false); err == nil {
+		t.Fatal("expected error for more values than the max width")
+	}
+}
+
+func TestShiftPoly(t *testing.T) {
+	fs := NewFFTSettings(4)
+	poly := make([]ff.Fr, 10, 10)
+	orig := make([]ff.Fr, 10, 10)
+	for i := range poly {
+		ff.CopyFr(&poly[i], ff.RandomFr())
+		ff.CopyFr(&orig[i], &poly[i])
+	}
+	fs.UnshiftPoly(poly)
+	var factor, expected ff.Fr
+	ff.AsFr(&factor, 5)
+	for i := range poly {
+		ff.ExpModFrUint64(&expected, &factor, uint64(i))
+		ff.MulModFr(&expected, &expected, &orig[i])
+		if !ff.EqualFr(&poly[i], &expected) {
+			t.Fatalf("coefficient %d differs: got %s, expected %s", i, ff.FrStr(&poly[i]), ff.FrStr(&expected))
+		}
+	}
+	fs.ShiftPoly(poly)
+	for i := range poly {
+		if !ff.EqualFr(&poly[i], &orig[i]) {
+			t.Fatalf("coefficient %d differs after shifting back: got %s, expected %s", i, ff.FrStr(&poly[i]), ff.FrStr(&orig[i]))
+		}
+	}
+}
+
+func TestShiftPowersCache(t *testing.T) {
+	fs := NewFFTSettings(6)
+	checkPowers := func(shift *ff.Fr, p *cosetPowers, n int) {
+		if len(p.powers) != n || len(p.invPowers) != n {
+			t.Fatalf("got %d and %d powers, expected %d", len(p.powers), len(p.invPowers), n)
+		}
+		var expected, prod ff.Fr
+		for i := range p.powers {
+			ff.ExpModFrUint64(&expected, shift, uint64(i))
+			ff.MulModFr(&prod, &p.powers[i], &p.invPowers[i])
+			if !ff.EqualFr(&p.powers[i], &expected) || !ff.EqualOne(&prod) {
+				t.Fatalf("power %d differs: got %s, expected %s", i, ff.FrStr(&p.powers[i]), ff.FrStr(&expected))
+			}
+		}
+	}
+	var shift ff.Fr
+	ff.AsFr(&shift, polyShiftFactor)
+	fs.ShiftPoly(make([]ff.Fr, 10, 10))
+	key := ff.FrTo32(&shift)
+	// only the powers needed so far are computed, not MaxWidth
+	if got := len(fs.cosets.shifts[key].powers); got != 10 {
+		t.Fatalf("expected 10 cached powers, got %d", got)
+	}
+	p, err := fs.shiftPowers(&shift, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkPowers(&shift, p, 12)
+	if got := len(fs.cosets.shifts[key].powers); got != 20 {
+		t.Fatalf("expected the cached powers to double to 20, got %d", got)
+	}
+
+	var wg sync.WaitGroup
+	var concurrent ff.Fr
+	ff.AsFr(&concurrent, 7)
+	results := make([]*cosetPowers, 8, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = fs.shiftPowers(&concurrent, uint64(8*(i+1)))
+		}(i)
+	}
+	wg.Wait()
+	for i, p := range results {
+		checkPowers(&concurrent, p, 8*(i+1))
+	}
+
+	for i := 0; i < 2*maxCachedCosetShifts; i++ {
+		var s ff.Fr
+		ff.AsFr(&s, uint64(100+i))
+		p, err := fs.shiftPowers(&s, fs.MaxWidth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkPowers(&s, p, int(fs.MaxWidth))
+	}
+	if len(fs.cosets.shifts) != maxCachedCosetShifts {
+		t.Fatalf("expected %d cached shifts, got %d", maxCachedCosetShifts, len(fs.cosets.shifts))
+	}
+}
+
+func TestShiftPolyBackend(t *testing.T) {
+	counter := &mulCountingBackend{Backend: ff.DefaultBackend()}
+	fs := NewFFTSettingsWithBackend(4, counter)
+	counter.muls = 0
+	fs.ShiftPoly(make([]ff.Fr, 10, 10))
+	// 9 multiplications each for the powers of the shift and its inverse, and 10 to scale the coefficients
+	if counter.muls != 28 {
+		t.Fatalf("expected all 28 multiplications on the backend of the settings, got %d", counter.muls)
+	}
+}
@@ -0,0 +1,31 @@
+package fft
+
+import (
+	"testing"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+// Runs the curve-independent tests again, on BN254, if the backend supports it.
+func TestBN254(t *testing.T) {
+	if err := ff.SetCurve(ff.BN254); err != nil {
+		t.Skip(err)
+	}
+	defer func() {
+		if err := ff.SetCurve(ff.BLS12_381); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if got := len(ff.Scale2RootOfUnity); got != 29 {
+		t.Fatalf("expected 2-adicity 28, got %d roots of unity", got)
+	}
+	t.Run("FFTRoundtrip", TestFFTRoundtrip)
+	t.Run("FFTBackendsAgree", TestFFTBackendsAgree)
+	t.Run("CosetFFT", TestCosetFFT)
+	t.Run("ErasureCodeRecoverSimple", TestErasureCodeRecoverSimple)
+	t.Run("PolyMul", TestPolyMul)
+	t.Run("PolyExtGCD", TestPolyExtGCD)
+	t.Run("PolySubProdTree", TestPolySubProdTree)
+	t.Run("PolyMultiPointEval", TestPolyMultiPointEval)
+	t.Run("FFTSettings_reduceLeaves", TestFFTSettings_reduceLeaves)
+}
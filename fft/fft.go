@@ -19,7 +19,7 @@ func nextPowOf2(v uint64) uint64 {
 // Expands the power circle for a given root of unity to WIDTH+1 values.
 // The first entry will be 1, the last entry will also be 1,
 // for convenience when reversing the array (useful for inverses)
-func expandRootOfUnity(RootOfUnity *ff.Fr) []ff.Fr {
+func expandRootOfUnity(backend ff.Backend, RootOfUnity *ff.Fr) []ff.Fr {
 	rootz := make([]ff.Fr, 2)
 	rootz[0] = ff.ONE // some unused number in py code
 	rootz[1] = *RootOfUnity
@@ -27,7 +27,7 @@ func expandRootOfUnity(RootOfUnity *ff.Fr) []ff.Fr {
 		rootz = append(rootz, ff.Fr{})
 		this := &rootz[i]
 		i++
-		ff.MulModFr(&rootz[i], this, RootOfUnity)
+		backend.MulModFr(&rootz[i], this, RootOfUnity)
 	}
 	return rootz
 }
@@ -40,12 +40,60 @@ type FFTSettings struct {
 	ExpandedRootsOfUnity []ff.Fr
 	// reverse domain, same as inverse values of domain. Also starting and ending with 1.
 	ReverseRootsOfUnity []ff.Fr
+	// the field and group operations used by the FFTs, nil means ff.DefaultBackend()
+	Backend ff.Backend
+	// MaxGoroutines enables parallel FFTs if larger than 1: FFT, FFTInPlace and FFTG1
+	// split the transform up over at most this many goroutines.
+	MaxGoroutines int
+	// ParallelCutoffDepth limits the recursion depth up to which the halves of a parallel transform
+	// are split up over goroutines. 0 means no limit other than MaxGoroutines. It does not change
+	// the number of goroutines the butterflies of the top levels are split up over.
+	ParallelCutoffDepth uint8
+	// Kernel selects the butterfly kernel, see FFTKernel.
+	Kernel FFTKernel
+	// BaseCaseSize is the size at or below which the kernels run a direct O(n^2) transform, 0 means 4.
+	BaseCaseSize uint64
+	// the kernel choice per scale, measured by TuneKernels
+	tunedKernels []kernelChoice
+	// the powers of the shifts used with CosetFFT
+	cosets *cosetCache
+}
+
+// backend returns the backend to run on, the default one if Backend is not set,
+// e.g. if the settings were created as struct literal.
+func (fs *FFTSettings) backend() ff.Backend {
+	if fs.Backend == nil {
+		return ff.DefaultBackend()
+	}
+	return fs.Backend
+}
+
+// parallelDepth returns the recursion depth up to which the halves of a parallel transform are split up
+// over goroutines, 0 if it runs serially. The butterflies are split up over MaxGoroutines, independently of
+// ParallelCutoffDepth.
+func (fs *FFTSettings) parallelDepth() uint8 {
+	if fs.MaxGoroutines <= 1 {
+		return 0
+	}
+	depth := uint8(bits.Len(uint(fs.MaxGoroutines)) - 1)
+	if fs.ParallelCutoffDepth != 0 && fs.ParallelCutoffDepth < depth {
+		depth = fs.ParallelCutoffDepth
+	}
+	return depth
 }
 
 func NewFFTSettings(maxScale uint8) *FFTSettings {
+	return NewFFTSettingsWithBackend(maxScale, ff.DefaultBackend())
+}
+
+// NewFFTSettingsWithBackend creates FFT settings that run on the given backend, see ff.GetBackend.
+func NewFFTSettingsWithBackend(maxScale uint8, backend ff.Backend) *FFTSettings {
+	if backend == nil {
+		backend = ff.DefaultBackend()
+	}
 	width := uint64(1) << maxScale
 	root := &ff.Scale2RootOfUnity[maxScale]
-	rootz := expandRootOfUnity(&ff.Scale2RootOfUnity[maxScale])
+	rootz := expandRootOfUnity(backend, &ff.Scale2RootOfUnity[maxScale])
 	// reverse roots of unity
 	rootzReverse := make([]ff.Fr, len(rootz), len(rootz))
 	copy(rootzReverse, rootz)
@@ -58,5 +106,7 @@ func NewFFTSettings(maxScale uint8) *FFTSettings {
 		RootOfUnity:          root,
 		ExpandedRootsOfUnity: rootz,
 		ReverseRootsOfUnity:  rootzReverse,
+		Backend:              backend,
+		cosets:               newCosetCache(),
 	}
 }
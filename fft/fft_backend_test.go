@@ -0,0 +1,85 @@
+package fft
+
+import (
+	"testing"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+func TestFFTBackendsAgree(t *testing.T) {
+	native := NewFFTSettings(5)
+	rng := ff.NewSeededReader(5)
+	data := make([]ff.Fr, native.MaxWidth, native.MaxWidth)
+	for i := range data {
+		data[i] = seededFr(t, rng)
+	}
+	expected, err := native.FFT(data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range ff.BackendNames() {
+		t.Run(name, func(t *testing.T) {
+			b, err := ff.GetBackend(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fs := NewFFTSettingsWithBackend(5, b)
+			got, err := fs.FFT(data, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range got {
+				if !ff.EqualFr(&got[i], &expected[i]) {
+					t.Errorf("difference at %d: got %s, expected %s", i, ff.FrStr(&got[i]), ff.FrStr(&expected[i]))
+				}
+			}
+			back, err := fs.FFT(got, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range back {
+				if !ff.EqualFr(&back[i], &data[i]) {
+					t.Errorf("roundtrip difference at %d: got %s, expected %s", i, ff.FrStr(&back[i]), ff.FrStr(&data[i]))
+				}
+			}
+		})
+	}
+}
+
+func TestFFTSettingsWithoutBackend(t *testing.T) {
+	expected := NewFFTSettings(4)
+	fs := &FFTSettings{
+		MaxWidth:             expected.MaxWidth,
+		RootOfUnity:          expected.RootOfUnity,
+		ExpandedRootsOfUnity: expected.ExpandedRootsOfUnity,
+		ReverseRootsOfUnity:  expected.ReverseRootsOfUnity,
+	}
+	rng := ff.NewSeededReader(4)
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+	for i := range data {
+		data[i] = seededFr(t, rng)
+	}
+	for _, inv := range []bool{false, true} {
+		want, err := expected.FFT(data, inv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := fs.FFT(data, inv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		inPlace := make([]ff.Fr, len(data), len(data))
+		for i := range data {
+			ff.CopyFr(&inPlace[i], &data[i])
+		}
+		if err := fs.FFTInPlace(inPlace, inv); err != nil {
+			t.Fatal(err)
+		}
+		for i := range want {
+			if !ff.EqualFr(&got[i], &want[i]) || !ff.EqualFr(&inPlace[i], &want[i]) {
+				t.Errorf("inv=%v, difference at %d: got %s and %s in place, expected %s", inv, i,
+					ff.FrStr(&got[i]), ff.FrStr(&inPlace[i]), ff.FrStr(&want[i]))
+			}
+		}
+	}
+}
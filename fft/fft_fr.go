@@ -7,49 +7,71 @@ import (
 )
 
 func (fs *FFTSettings) simpleFT(vals []ff.Fr, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr) {
+	b := fs.backend()
 	l := uint64(len(out))
+	if l == 1 {
+		// the transform of a single value is the value itself
+		ff.CopyFr(&out[0], &vals[valsOffset])
+		return
+	}
 	var v ff.Fr
 	var tmp ff.Fr
 	var last ff.Fr
 	for i := uint64(0); i < l; i++ {
 		jv := &vals[valsOffset]
 		r := &rootsOfUnity[0]
-		ff.MulModFr(&v, jv, r)
+		b.MulModFr(&v, jv, r)
 		ff.CopyFr(&last, &v)
 
 		for j := uint64(1); j < l; j++ {
 			jv := &vals[valsOffset+j*valsStride]
 			r := &rootsOfUnity[((i*j)%l)*rootsOfUnityStride]
-			ff.MulModFr(&v, jv, r)
+			b.MulModFr(&v, jv, r)
 			ff.CopyFr(&tmp, &last)
-			ff.AddModFr(&last, &tmp, &v)
+			b.AddModFr(&last, &tmp, &v)
 		}
 		ff.CopyFr(&out[i], &last)
 	}
 }
 
-func (fs *FFTSettings) _fft(vals []ff.Fr, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr) {
-	if len(out) <= 4 { // if the value count is small, run the unoptimized version instead. // TODO tune threshold.
+func (fs *FFTSettings) _fft(vals []ff.Fr, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr, baseCase uint64) {
+	if uint64(len(out)) <= baseCase || len(out) == 1 { // if the value count is small, run the unoptimized version instead.
 		fs.simpleFT(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out)
 		return
 	}
 
 	half := uint64(len(out)) >> 1
 	// L will be the left half of out
-	fs._fft(vals, valsOffset, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[:half])
+	fs._fft(vals, valsOffset, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[:half], baseCase)
 	// R will be the right half of out
-	fs._fft(vals, valsOffset+valsStride, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[half:]) // just take even again
+	fs._fft(vals, valsOffset+valsStride, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[half:], baseCase) // just take even again
+	fs.radix2Butterflies(rootsOfUnity, rootsOfUnityStride, out)
+}
 
+// radix2Butterflies combines the transforms of the even and odd values, in the left and right half of out.
+func (fs *FFTSettings) radix2Butterflies(rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr) {
+	fs.radix2ButterfliesRange(rootsOfUnity, rootsOfUnityStride, out, 0, uint64(len(out))>>1)
+}
+
+// radix2ButterfliesRange runs the butterflies of radix2Butterflies for the indices [start, end) of the left half.
+func (fs *FFTSettings) radix2ButterfliesRange(rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr, start, end uint64) {
+	half := uint64(len(out)) >> 1
+	b := fs.backend()
 	var yTimesRoot ff.Fr
 	var x, y ff.Fr
-	for i := uint64(0); i < half; i++ {
+	for i := start; i < end; i++ {
 		// temporary copies, so that writing to output doesn't conflict with input
 		ff.CopyFr(&x, &out[i])
 		ff.CopyFr(&y, &out[i+half])
-		root := &rootsOfUnity[i*rootsOfUnityStride]
-		ff.MulModFr(&yTimesRoot, &y, root)
-		ff.AddModFr(&out[i], &x, &yTimesRoot)
-		ff.SubModFr(&out[i+half], &x, &yTimesRoot)
+		if i == 0 {
+			// the first root is one
+			ff.CopyFr(&yTimesRoot, &y)
+		} else {
+			root := &rootsOfUnity[i*rootsOfUnityStride]
+			b.MulModFr(&yTimesRoot, &y, root)
+		}
+		b.AddModFr(&out[i], &x, &yTimesRoot)
+		b.SubModFr(&out[i+half], &x, &yTimesRoot)
 	}
 }
 
@@ -67,11 +89,23 @@ func (fs *FFTSettings) FFT(vals []ff.Fr, inv bool) ([]ff.Fr, error) {
 	for i := uint64(len(vals)); i < n; i++ {
 		ff.CopyFr(&valsCopy[i], &ff.ZERO)
 	}
-	out := make([]ff.Fr, n, n)
-	if err := fs.InplaceFFT(valsCopy, out, inv); err != nil {
+	return fs.fftOwned(valsCopy, inv)
+}
+
+// fftOwned transforms values the caller owns, with the configured kernel. The values may be overwritten,
+// and are returned as output if the kernel runs in place.
+func (fs *FFTSettings) fftOwned(vals []ff.Fr, inv bool) ([]ff.Fr, error) {
+	if fs.Kernel != KernelDefault {
+		out := make([]ff.Fr, len(vals), len(vals))
+		if err := fs.InplaceFFT(vals, out, inv); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+	if err := fs.FFTInPlace(vals, inv); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return vals, nil
 }
 
 func (fs *FFTSettings) InplaceFFT(vals []ff.Fr, out []ff.Fr, inv bool) error {
@@ -85,14 +119,15 @@ func (fs *FFTSettings) InplaceFFT(vals []ff.Fr, out []ff.Fr, inv bool) error {
 	if inv {
 		var invLen ff.Fr
 		ff.AsFr(&invLen, n)
-		ff.InvModFr(&invLen, &invLen)
+		fs.backend().InvModFr(&invLen, &invLen)
 		rootz := fs.ReverseRootsOfUnity[:fs.MaxWidth]
 		stride := fs.MaxWidth / n
 
-		fs._fft(vals, 0, 1, rootz, stride, out)
+		fs.kernelFFT(vals, rootz, stride, out)
+		b := fs.backend()
 		var tmp ff.Fr
 		for i := 0; i < len(out); i++ {
-			ff.MulModFr(&tmp, &out[i], &invLen)
+			b.MulModFr(&tmp, &out[i], &invLen)
 			ff.CopyFr(&out[i], &tmp) // TODO: depending on Fr implementation, allow to directly write back to an input
 		}
 		return nil
@@ -100,7 +135,7 @@ func (fs *FFTSettings) InplaceFFT(vals []ff.Fr, out []ff.Fr, inv bool) error {
 		rootz := fs.ExpandedRootsOfUnity[:fs.MaxWidth]
 		stride := fs.MaxWidth / n
 		// Regular FFT
-		fs._fft(vals, 0, 1, rootz, stride, out)
+		fs.kernelFFT(vals, rootz, stride, out)
 		return nil
 	}
 }
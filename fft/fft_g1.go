@@ -1,4 +1,4 @@
-// +build !bignum_pure,!bignum_hol256
+// +build !bignum_hol256
 
 package fft
 
@@ -9,6 +9,7 @@ import (
 )
 
 func (fs *FFTSettings) simpleFTG1(vals []ff.G1Point, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.G1Point) {
+	b := fs.backend()
 	l := uint64(len(out))
 	var v ff.G1Point
 	var tmp ff.G1Point
@@ -16,15 +17,15 @@ func (fs *FFTSettings) simpleFTG1(vals []ff.G1Point, valsOffset uint64, valsStri
 	for i := uint64(0); i < l; i++ {
 		jv := &vals[valsOffset]
 		r := &rootsOfUnity[0]
-		ff.MulG1(&v, jv, r)
+		b.MulG1(&v, jv, r)
 		ff.CopyG1(&last, &v)
 
 		for j := uint64(1); j < l; j++ {
 			jv := &vals[valsOffset+j*valsStride]
 			r := &rootsOfUnity[((i*j)%l)*rootsOfUnityStride]
-			ff.MulG1(&v, jv, r)
+			b.MulG1(&v, jv, r)
 			ff.CopyG1(&tmp, &last)
-			ff.AddG1(&last, &tmp, &v)
+			b.AddG1(&last, &tmp, &v)
 		}
 		ff.CopyG1(&out[i], &last)
 	}
@@ -42,6 +43,7 @@ func (fs *FFTSettings) _fftG1(vals []ff.G1Point, valsOffset uint64, valsStride u
 	// R will be the right half of out
 	fs._fftG1(vals, valsOffset+valsStride, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[half:]) // just take even again
 
+	b := fs.backend()
 	var yTimesRoot ff.G1Point
 	var x, y ff.G1Point
 	for i := uint64(0); i < half; i++ {
@@ -49,12 +51,42 @@ func (fs *FFTSettings) _fftG1(vals []ff.G1Point, valsOffset uint64, valsStride u
 		ff.CopyG1(&x, &out[i])
 		ff.CopyG1(&y, &out[i+half])
 		root := &rootsOfUnity[i*rootsOfUnityStride]
-		ff.MulG1(&yTimesRoot, &y, root)
-		ff.AddG1(&out[i], &x, &yTimesRoot)
-		ff.SubG1(&out[i+half], &x, &yTimesRoot)
+		b.MulG1(&yTimesRoot, &y, root)
+		b.AddG1(&out[i], &x, &yTimesRoot)
+		b.SubG1(&out[i+half], &x, &yTimesRoot)
 	}
 }
 
+// _fftG1Parallel is _fftG1, with the two halves of the remaining depth levels transformed concurrently.
+// The butterflies of each level are split up over goroutines, halved for each of the two halves.
+func (fs *FFTSettings) _fftG1Parallel(vals []ff.G1Point, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.G1Point, depth uint8, goroutines int) {
+	if depth == 0 || len(out) < minParallelFFTG1Size {
+		fs._fftG1(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out)
+		return
+	}
+	half := uint64(len(out)) >> 1
+	parallelHalves(func() {
+		fs._fftG1Parallel(vals, valsOffset, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[:half], depth-1, goroutines>>1)
+	}, func() {
+		fs._fftG1Parallel(vals, valsOffset+valsStride, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[half:], depth-1, goroutines>>1)
+	})
+
+	b := fs.backend()
+	parallelChunks(half, goroutines, func(start, end uint64) {
+		var yTimesRoot ff.G1Point
+		var x, y ff.G1Point
+		for i := start; i < end; i++ {
+			// temporary copies, so that writing to output doesn't conflict with input
+			ff.CopyG1(&x, &out[i])
+			ff.CopyG1(&y, &out[i+half])
+			root := &rootsOfUnity[i*rootsOfUnityStride]
+			b.MulG1(&yTimesRoot, &y, root)
+			b.AddG1(&out[i], &x, &yTimesRoot)
+			b.SubG1(&out[i+half], &x, &yTimesRoot)
+		}
+	})
+}
+
 func (fs *FFTSettings) FFTG1(vals []ff.G1Point, inv bool) ([]ff.G1Point, error) {
 	n := uint64(len(vals))
 	if n > fs.MaxWidth {
@@ -71,24 +103,27 @@ func (fs *FFTSettings) FFTG1(vals []ff.G1Point, inv bool) ([]ff.G1Point, error)
 	if inv {
 		var invLen ff.Fr
 		ff.AsFr(&invLen, n)
-		ff.InvModFr(&invLen, &invLen)
+		fs.backend().InvModFr(&invLen, &invLen)
 		rootz := fs.ReverseRootsOfUnity[:fs.MaxWidth]
 		stride := fs.MaxWidth / n
 
 		out := make([]ff.G1Point, n, n)
-		fs._fftG1(valsCopy, 0, 1, rootz, stride, out)
-		var tmp ff.G1Point
-		for i := 0; i < len(out); i++ {
-			ff.MulG1(&tmp, &out[i], &invLen)
-			ff.CopyG1(&out[i], &tmp)
-		}
+		fs._fftG1Parallel(valsCopy, 0, 1, rootz, stride, out, fs.parallelDepth(), fs.MaxGoroutines)
+		b := fs.backend()
+		parallelChunks(n, fs.MaxGoroutines, func(start, end uint64) {
+			var tmp ff.G1Point
+			for i := start; i < end; i++ {
+				b.MulG1(&tmp, &out[i], &invLen)
+				ff.CopyG1(&out[i], &tmp)
+			}
+		})
 		return out, nil
 	} else {
 		out := make([]ff.G1Point, n, n)
 		rootz := fs.ExpandedRootsOfUnity[:fs.MaxWidth]
 		stride := fs.MaxWidth / n
 		// Regular FFT
-		fs._fftG1(valsCopy, 0, 1, rootz, stride, out)
+		fs._fftG1Parallel(valsCopy, 0, 1, rootz, stride, out, fs.parallelDepth(), fs.MaxGoroutines)
 		return out, nil
 	}
 }
@@ -1,16 +1,18 @@
-// +build !bignum_pure,!bignum_hol256
+// +build !bignum_hol256
 
 package fft
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
 
 	"github.com/sshravan/go-poly/ff"
 )
 
-func benchFFTG1(scale uint8, b *testing.B) {
+func benchFFTG1(scale uint8, maxGoroutines int, b *testing.B) {
 	fs := NewFFTSettings(scale)
+	fs.MaxGoroutines = maxGoroutines
 	data := make([]ff.G1Point, fs.MaxWidth, fs.MaxWidth)
 	for i := uint64(0); i < fs.MaxWidth; i++ {
 		var tmpG1 ff.G1Point
@@ -32,7 +34,15 @@ func benchFFTG1(scale uint8, b *testing.B) {
 func BenchmarkFFTSettings_FFTG1(b *testing.B) {
 	for scale := uint8(4); scale < 16; scale++ {
 		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
-			benchFFTG1(scale, b)
+			benchFFTG1(scale, 1, b)
+		})
+	}
+}
+
+func BenchmarkFFTSettings_FFTG1Parallel(b *testing.B) {
+	for scale := uint8(4); scale < 16; scale++ {
+		b.Run(fmt.Sprintf("scale_%d", scale), func(b *testing.B) {
+			benchFFTG1(scale, runtime.NumCPU(), b)
 		})
 	}
 }
@@ -0,0 +1,35 @@
+// +build !bignum_hol256
+
+package fft
+
+import (
+	"testing"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+func TestParallelFFTG1(t *testing.T) {
+	serial := NewFFTSettings(6)
+	parallel := NewFFTSettings(6)
+	parallel.MaxGoroutines = 4
+	n := serial.MaxWidth
+	data := make([]ff.G1Point, n, n)
+	for i := range data {
+		ff.MulG1(&data[i], &ff.GenG1, ff.RandomFr())
+	}
+	for _, inv := range []bool{false, true} {
+		expected, err := serial.FFTG1(data, inv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := parallel.FFTG1(data, inv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range got {
+			if !ff.EqualG1(&got[i], &expected[i]) {
+				t.Fatalf("inv %v: point %d differs: got %s, expected %s", inv, i, ff.StrG1(&got[i]), ff.StrG1(&expected[i]))
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// +build !bignum_hol256
+
+package fft
+
+import (
+	"fmt"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+func (fs *FFTSettings) simpleFTG2(vals []ff.G2Point, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.G2Point) {
+	l := uint64(len(out))
+	var v ff.G2Point
+	var tmp ff.G2Point
+	var last ff.G2Point
+	for i := uint64(0); i < l; i++ {
+		jv := &vals[valsOffset]
+		r := &rootsOfUnity[0]
+		ff.MulG2(&v, jv, r)
+		ff.CopyG2(&last, &v)
+
+		for j := uint64(1); j < l; j++ {
+			jv := &vals[valsOffset+j*valsStride]
+			r := &rootsOfUnity[((i*j)%l)*rootsOfUnityStride]
+			ff.MulG2(&v, jv, r)
+			ff.CopyG2(&tmp, &last)
+			ff.AddG2(&last, &tmp, &v)
+		}
+		ff.CopyG2(&out[i], &last)
+	}
+}
+
+func (fs *FFTSettings) _fftG2(vals []ff.G2Point, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.G2Point) {
+	if len(out) <= 4 { // if the value count is small, run the unoptimized version instead.
+		fs.simpleFTG2(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out)
+		return
+	}
+
+	half := uint64(len(out)) >> 1
+	// L will be the left half of out
+	fs._fftG2(vals, valsOffset, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[:half])
+	// R will be the right half of out
+	fs._fftG2(vals, valsOffset+valsStride, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[half:]) // just take even again
+
+	var yTimesRoot ff.G2Point
+	var x, y ff.G2Point
+	for i := uint64(0); i < half; i++ {
+		// temporary copies, so that writing to output doesn't conflict with input
+		ff.CopyG2(&x, &out[i])
+		ff.CopyG2(&y, &out[i+half])
+		root := &rootsOfUnity[i*rootsOfUnityStride]
+		ff.MulG2(&yTimesRoot, &y, root)
+		ff.AddG2(&out[i], &x, &yTimesRoot)
+		ff.SubG2(&out[i+half], &x, &yTimesRoot)
+	}
+}
+
+// _fftG2Parallel is _fftG2, with the two halves of the remaining depth levels transformed concurrently.
+// The butterflies of each level are split up over goroutines, halved for each of the two halves.
+func (fs *FFTSettings) _fftG2Parallel(vals []ff.G2Point, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.G2Point, depth uint8, goroutines int) {
+	if depth == 0 || len(out) < minParallelFFTG2Size {
+		fs._fftG2(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out)
+		return
+	}
+	half := uint64(len(out)) >> 1
+	parallelHalves(func() {
+		fs._fftG2Parallel(vals, valsOffset, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[:half], depth-1, goroutines>>1)
+	}, func() {
+		fs._fftG2Parallel(vals, valsOffset+valsStride, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[half:], depth-1, goroutines>>1)
+	})
+
+	parallelChunks(half, goroutines, func(start, end uint64) {
+		var yTimesRoot ff.G2Point
+		var x, y ff.G2Point
+		for i := start; i < end; i++ {
+			// temporary copies, so that writing to output doesn't conflict with input
+			ff.CopyG2(&x, &out[i])
+			ff.CopyG2(&y, &out[i+half])
+			root := &rootsOfUnity[i*rootsOfUnityStride]
+			ff.MulG2(&yTimesRoot, &y, root)
+			ff.AddG2(&out[i], &x, &yTimesRoot)
+			ff.SubG2(&out[i+half], &x, &yTimesRoot)
+		}
+	})
+}
+
+// FFTG2 is FFTG1 for G2 points. ff.Backend only covers G1, the G2 operations are the native ones.
+func (fs *FFTSettings) FFTG2(vals []ff.G2Point, inv bool) ([]ff.G2Point, error) {
+	n := uint64(len(vals))
+	if n > fs.MaxWidth {
+		return nil, fmt.Errorf("got %d values but only have %d roots of unity", n, fs.MaxWidth)
+	}
+	if !ff.IsPowerOfTwo(n) {
+		return nil, fmt.Errorf("got %d values but not a power of two", n)
+	}
+	// We make a copy so we can mutate it during the work.
+	valsCopy := make([]ff.G2Point, n, n)
+	for i := 0; i < len(vals); i++ {
+		ff.CopyG2(&valsCopy[i], &vals[i])
+	}
+	if inv {
+		var invLen ff.Fr
+		ff.AsFr(&invLen, n)
+		fs.backend().InvModFr(&invLen, &invLen)
+		rootz := fs.ReverseRootsOfUnity[:fs.MaxWidth]
+		stride := fs.MaxWidth / n
+
+		out := make([]ff.G2Point, n, n)
+		fs._fftG2Parallel(valsCopy, 0, 1, rootz, stride, out, fs.parallelDepth(), fs.MaxGoroutines)
+		parallelChunks(n, fs.MaxGoroutines, func(start, end uint64) {
+			var tmp ff.G2Point
+			for i := start; i < end; i++ {
+				ff.MulG2(&tmp, &out[i], &invLen)
+				ff.CopyG2(&out[i], &tmp)
+			}
+		})
+		return out, nil
+	} else {
+		out := make([]ff.G2Point, n, n)
+		rootz := fs.ExpandedRootsOfUnity[:fs.MaxWidth]
+		stride := fs.MaxWidth / n
+		// Regular FFT
+		fs._fftG2Parallel(valsCopy, 0, 1, rootz, stride, out, fs.parallelDepth(), fs.MaxGoroutines)
+		return out, nil
+	}
+}
+
+// rearrange G2 elements in reverse bit order. Supports 2**31 max element count.
+func ReverseBitOrderG2(values []ff.G2Point) {
+	if len(values) > (1 << 31) {
+		panic("list too large")
+	}
+	var tmp ff.G2Point
+	reverseBitOrder(uint32(len(values)), func(i, j uint32) {
+		ff.CopyG2(&tmp, &values[i])
+		ff.CopyG2(&values[i], &values[j])
+		ff.CopyG2(&values[j], &tmp)
+	})
+}
@@ -0,0 +1,89 @@
+// +build !bignum_hol256
+
+package fft
+
+import (
+	"testing"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+func TestFFTG2(t *testing.T) {
+	fs := NewFFTSettings(4)
+	n := fs.MaxWidth
+	scalars := make([]ff.Fr, n, n)
+	points := make([]ff.G2Point, n, n)
+	for i := range scalars {
+		ff.CopyFr(&scalars[i], ff.RandomFr())
+		ff.MulG2(&points[i], &ff.GenG2, &scalars[i])
+	}
+	for _, inv := range []bool{false, true} {
+		// the transform is linear, so it commutes with the multiplication by the generator
+		expectedScalars, err := fs.FFT(scalars, inv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := fs.FFTG2(points, inv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var expected ff.G2Point
+		for i := range got {
+			ff.MulG2(&expected, &ff.GenG2, &expectedScalars[i])
+			if !ff.EqualG2(&got[i], &expected) {
+				t.Fatalf("inv %v: point %d differs: got %s, expected %s", inv, i, ff.StrG2(&got[i]), ff.StrG2(&expected))
+			}
+		}
+		back, err := fs.FFTG2(got, !inv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range back {
+			if !ff.EqualG2(&back[i], &points[i]) {
+				t.Fatalf("inv %v: roundtrip point %d differs: got %s, expected %s", inv, i, ff.StrG2(&back[i]), ff.StrG2(&points[i]))
+			}
+		}
+	}
+
+	parallel := NewFFTSettings(4)
+	parallel.MaxGoroutines = 4
+	expected, err := fs.FFTG2(points, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := parallel.FFTG2(points, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range got {
+		if !ff.EqualG2(&got[i], &expected[i]) {
+			t.Fatalf("parallel: point %d differs: got %s, expected %s", i, ff.StrG2(&got[i]), ff.StrG2(&expected[i]))
+		}
+	}
+
+	if _, err := fs.FFTG2(points[:3], false); err == nil {
+		t.Fatal("expected error for a non power of two length")
+	}
+	if _, err := fs.FFTG2(make([]ff.G2Point, 2*n), false); err == nil {
+		t.Fatal("expected error for a length larger than the max width")
+	}
+}
+
+func TestReverseBitOrderG2(t *testing.T) {
+	n := 16
+	scalars := make([]ff.Fr, n, n)
+	points := make([]ff.G2Point, n, n)
+	for i := range scalars {
+		ff.AsFr(&scalars[i], uint64(i+1))
+		ff.MulG2(&points[i], &ff.GenG2, &scalars[i])
+	}
+	ReverseBitOrderFr(scalars)
+	ReverseBitOrderG2(points)
+	var expected ff.G2Point
+	for i := range points {
+		ff.MulG2(&expected, &ff.GenG2, &scalars[i])
+		if !ff.EqualG2(&points[i], &expected) {
+			t.Fatalf("point %d differs: got %s, expected %s", i, ff.StrG2(&points[i]), ff.StrG2(&expected))
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package fft
+
+import (
+	"fmt"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+// checkInPlaceWidth checks that n values can be transformed, and returns the stride into the roots of unity.
+func (fs *FFTSettings) checkInPlaceWidth(n uint64) (uint64, error) {
+	if n > fs.MaxWidth {
+		return 0, fmt.Errorf("got %d values but only have %d roots of unity", n, fs.MaxWidth)
+	}
+	if !ff.IsPowerOfTwo(n) {
+		return 0, fmt.Errorf("got %d values but not a power of two", n)
+	}
+	return fs.MaxWidth / n, nil
+}
+
+func (fs *FFTSettings) inPlaceRoots(inv bool) []ff.Fr {
+	if inv {
+		return fs.ReverseRootsOfUnity[:fs.MaxWidth]
+	}
+	return fs.ExpandedRootsOfUnity[:fs.MaxWidth]
+}
+
+// Decimation in frequency (Gentleman-Sande): natural order input, bit-reversed order output.
+func (fs *FFTSettings) difFFT(vals []ff.Fr, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64) {
+	b := fs.backend()
+	n := uint64(len(vals))
+	var x, y, diff ff.Fr
+	for half := n >> 1; half > 0; half >>= 1 {
+		stride := rootsOfUnityStride * (n / (half << 1))
+		for start := uint64(0); start < n; start += half << 1 {
+			for j := uint64(0); j < half; j++ {
+				ff.CopyFr(&x, &vals[start+j])
+				ff.CopyFr(&y, &vals[start+j+half])
+				b.AddModFr(&vals[start+j], &x, &y)
+				b.SubModFr(&diff, &x, &y)
+				if j == 0 {
+					ff.CopyFr(&vals[start+j+half], &diff)
+				} else {
+					b.MulModFr(&vals[start+j+half], &diff, &rootsOfUnity[j*stride])
+				}
+			}
+		}
+	}
+}
+
+// Decimation in time (Cooley-Tukey): bit-reversed order input, natural order output.
+func (fs *FFTSettings) ditFFT(vals []ff.Fr, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64) {
+	b := fs.backend()
+	n := uint64(len(vals))
+	var x, yTimesRoot ff.Fr
+	for half := uint64(1); half < n; half <<= 1 {
+		stride := rootsOfUnityStride * (n / (half << 1))
+		for start := uint64(0); start < n; start += half << 1 {
+			for j := uint64(0); j < half; j++ {
+				ff.CopyFr(&x, &vals[start+j])
+				if j == 0 {
+					ff.CopyFr(&yTimesRoot, &vals[start+j+half])
+				} else {
+					b.MulModFr(&yTimesRoot, &vals[start+j+half], &rootsOfUnity[j*stride])
+				}
+				b.AddModFr(&vals[start+j], &x, &yTimesRoot)
+				b.SubModFr(&vals[start+j+half], &x, &yTimesRoot)
+			}
+		}
+	}
+}
+
+// scaleInv multiplies the values by 1/n, to complete an inverse transform.
+func (fs *FFTSettings) scaleInv(vals []ff.Fr) {
+	var invLen, tmp ff.Fr
+	ff.AsFr(&invLen, uint64(len(vals)))
+	fs.backend().InvModFr(&invLen, &invLen)
+	for i := range vals {
+		fs.backend().MulModFr(&tmp, &vals[i], &invLen)
+		ff.CopyFr(&vals[i], &tmp)
+	}
+}
+
+// FFTInPlace transforms the values in place, without recursion or an output buffer.
+// The length must be a power of two, up to MaxWidth. Unlike FFT, the input is not padded.
+// Input and output are in natural order, the bit-reversal permutation is applied internally.
+func (fs *FFTSettings) FFTInPlace(vals []ff.Fr, inv bool) error {
+	if err := fs.FFTDIF(vals, inv); err != nil {
+		return err
+	}
+	ReverseBitOrderFr(vals)
+	return nil
+}
+
+// FFTDIF transforms the values in place, from natural order to bit-reversed order, skipping the permutation.
+// Followed by FFTDIT, e.g. to multiply evaluations point-wise in between, no permutation is needed at all.
+func (fs *FFTSettings) FFTDIF(vals []ff.Fr, inv bool) error {
+	stride, err := fs.checkInPlaceWidth(uint64(len(vals)))
+	if err != nil {
+		return err
+	}
+	fs.difFFTParallel(vals, fs.inPlaceRoots(inv), stride, fs.parallelDepth(), fs.MaxGoroutines)
+	if inv {
+		fs.scaleInv(vals)
+	}
+	return nil
+}
+
+// FFTDIT transforms the values in place, from bit-reversed order to natural order, the counterpart of FFTDIF.
+func (fs *FFTSettings) FFTDIT(vals []ff.Fr, inv bool) error {
+	stride, err := fs.checkInPlaceWidth(uint64(len(vals)))
+	if err != nil {
+		return err
+	}
+	fs.ditFFTParallel(vals, fs.inPlaceRoots(inv), stride, fs.parallelDepth(), fs.MaxGoroutines)
+	if inv {
+		fs.scaleInv(vals)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package fft
+
+import (
+	"testing"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+func TestFFTInPlace(t *testing.T) {
+	fs := NewFFTSettings(8)
+	for scale := uint8(0); scale <= 8; scale++ {
+		n := uint64(1) << scale
+		data := make([]ff.Fr, n, n)
+		for i := range data {
+			ff.CopyFr(&data[i], ff.RandomFr())
+		}
+		for _, inv := range []bool{false, true} {
+			expected := make([]ff.Fr, n, n)
+			if err := fs.InplaceFFT(data, expected, inv); err != nil {
+				t.Fatal(err)
+			}
+			got := make([]ff.Fr, n, n)
+			for i := range data {
+				ff.CopyFr(&got[i], &data[i])
+			}
+			if err := fs.FFTInPlace(got, inv); err != nil {
+				t.Fatal(err)
+			}
+			for i := range got {
+				if !ff.EqualFr(&got[i], &expected[i]) {
+					t.Fatalf("scale %d, inv %v: value %d differs: got %s, expected %s", scale, inv, i, ff.FrStr(&got[i]), ff.FrStr(&expected[i]))
+				}
+			}
+		}
+	}
+}
+
+func TestFFTDIFDIT(t *testing.T) {
+	fs := NewFFTSettings(6)
+	n := fs.MaxWidth
+	data := make([]ff.Fr, n, n)
+	for i := range data {
+		ff.CopyFr(&data[i], ff.RandomFr())
+	}
+	evals, err := fs.FFT(data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// DIF leaves the evaluations in bit-reversed order
+	dif := make([]ff.Fr, n, n)
+	for i := range data {
+		ff.CopyFr(&dif[i], &data[i])
+	}
+	if err := fs.FFTDIF(dif, false); err != nil {
+		t.Fatal(err)
+	}
+	ReverseBitOrderFr(dif)
+	for i := range dif {
+		if !ff.EqualFr(&dif[i], &evals[i]) {
+			t.Fatalf("DIF value %d differs: got %s, expected %s", i, ff.FrStr(&dif[i]), ff.FrStr(&evals[i]))
+		}
+	}
+	// and DIT inverse takes them back without a permutation
+	ReverseBitOrderFr(dif)
+	if err := fs.FFTDIT(dif, true); err != nil {
+		t.Fatal(err)
+	}
+	for i := range dif {
+		if !ff.EqualFr(&dif[i], &data[i]) {
+			t.Fatalf("roundtrip value %d differs: got %s, expected %s", i, ff.FrStr(&dif[i]), ff.FrStr(&data[i]))
+		}
+	}
+}
+
+func TestFFTInPlaceErrors(t *testing.T) {
+	fs := NewFFTSettings(4)
+	if err := fs.FFTInPlace(make([]ff.Fr, 3, 3), false); err == nil {
+		t.Fatal("expected error for a non power of two length")
+	}
+	if err := fs.FFTInPlace(make([]ff.Fr, 32, 32), false); err == nil {
+		t.Fatal("expected error for a length larger than the max width")
+	}
+}
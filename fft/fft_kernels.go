@@ -0,0 +1,261 @@
+package fft
+
+import (
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+// FFTKernel selects the butterfly kernel of the out-of-place transforms (InplaceFFT, and FFT if not the default).
+//
+// All kernels skip the multiplications by the trivial twiddle factor 1, and with a base case size of 1
+// they need (n/2)*log2(n) - n + 1 multiplications.
+// Unlike complex FFTs, multiplying by i = w^(n/4) is a full field multiplication, so radix-4 and split-radix
+// do not save multiplications over radix-2 here: they differ in the number of passes over memory and recursion depth,
+// which is what TuneKernels measures.
+type FFTKernel uint8
+
+const (
+	// KernelDefault: FFT runs the in-place radix-2 kernel of FFTInPlace, InplaceFFT the recursive radix-2 kernel.
+	KernelDefault FFTKernel = iota
+	// KernelRadix2 splits the transform in two halves per level, one multiplication per butterfly.
+	KernelRadix2
+	// KernelRadix4 splits the transform in four quarters per level, combining two radix-2 levels in one pass.
+	KernelRadix4
+	// KernelSplitRadix splits the transform in a half and two quarters per level.
+	KernelSplitRadix
+	// KernelIterative copies the values to the output, and runs the iterative radix-2 kernel of FFTInPlace on it.
+	// It does not use a base case, and does the fewest passes over memory.
+	KernelIterative
+	// KernelAuto picks the kernel and base case size per transform size, measured with TuneKernels.
+	KernelAuto
+)
+
+func (k FFTKernel) String() string {
+	switch k {
+	case KernelDefault:
+		return "default"
+	case KernelRadix2:
+		return "radix-2"
+	case KernelRadix4:
+		return "radix-4"
+	case KernelSplitRadix:
+		return "split-radix"
+	case KernelIterative:
+		return "iterative"
+	case KernelAuto:
+		return "auto"
+	default:
+		return fmt.Sprintf("FFTKernel(%d)", uint8(k))
+	}
+}
+
+// Transforms of this size or smaller run the O(n^2) simpleFT, unless FFTSettings.BaseCaseSize is set.
+const defaultBaseCaseSize = 4
+
+// Transform sizes TuneKernels tries, larger sizes use the choice of the largest tuned size.
+const maxTuneScale = 12
+
+type kernelChoice struct {
+	kernel   FFTKernel
+	baseCase uint64
+}
+
+// kernelFor returns the kernel and base case size to transform n values with.
+func (fs *FFTSettings) kernelFor(n uint64) kernelChoice {
+	if fs.Kernel == KernelAuto {
+		if len(fs.tunedKernels) == 0 {
+			return kernelChoice{kernel: KernelSplitRadix, baseCase: 1}
+		}
+		scale := bits.Len64(n) - 1
+		if scale >= len(fs.tunedKernels) {
+			scale = len(fs.tunedKernels) - 1
+		}
+		return fs.tunedKernels[scale]
+	}
+	baseCase := fs.BaseCaseSize
+	if baseCase == 0 {
+		baseCase = defaultBaseCaseSize
+	}
+	return kernelChoice{kernel: fs.Kernel, baseCase: baseCase}
+}
+
+// kernelFFT transforms the values into out, with the kernel chosen for the size. len(out) must be a power of two.
+// With MaxGoroutines set, the top levels are split in radix-2 halves that run the kernel concurrently.
+func (fs *FFTSettings) kernelFFT(vals []ff.Fr, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr) {
+	fs.kernelFFTParallel(vals, 0, 1, rootsOfUnity, rootsOfUnityStride, out, fs.parallelDepth(), fs.MaxGoroutines)
+}
+
+// kernelFFTParallel transforms the even and odd values of the remaining depth levels concurrently, and combines
+// them with the butterflies split up over goroutines, halved for each of the two halves.
+// Below that, the kernel chosen for the size of the remaining transform runs on a single goroutine.
+func (fs *FFTSettings) kernelFFTParallel(vals []ff.Fr, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr, depth uint8, goroutines int) {
+	n := uint64(len(out))
+	if depth == 0 || n < minParallelFFTSize {
+		fs.runKernel(fs.kernelFor(n), vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out)
+		return
+	}
+	half := n >> 1
+	parallelHalves(func() {
+		fs.kernelFFTParallel(vals, valsOffset, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[:half], depth-1, goroutines>>1)
+	}, func() {
+		fs.kernelFFTParallel(vals, valsOffset+valsStride, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[half:], depth-1, goroutines>>1)
+	})
+	parallelChunks(half, goroutines, func(start, end uint64) {
+		fs.radix2ButterfliesRange(rootsOfUnity, rootsOfUnityStride, out, start, end)
+	})
+}
+
+func (fs *FFTSettings) runKernel(c kernelChoice, vals []ff.Fr, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr) {
+	switch c.kernel {
+	case KernelRadix4:
+		fs._fftRadix4(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out, c.baseCase)
+	case KernelSplitRadix:
+		fs._fftSplitRadix(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out, c.baseCase)
+	case KernelIterative:
+		for i := range out {
+			ff.CopyFr(&out[i], &vals[valsOffset+uint64(i)*valsStride])
+		}
+		fs.difFFT(out, rootsOfUnity, rootsOfUnityStride)
+		ReverseBitOrderFr(out)
+	default:
+		fs._fft(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out, c.baseCase)
+	}
+}
+
+func (fs *FFTSettings) _fftRadix4(vals []ff.Fr, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr, baseCase uint64) {
+	n := uint64(len(out))
+	if n <= baseCase || n == 1 {
+		fs.simpleFT(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out)
+		return
+	}
+	if n%4 != 0 {
+		// odd power of two, split this level in halves
+		half := n >> 1
+		fs._fftRadix4(vals, valsOffset, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[:half], baseCase)
+		fs._fftRadix4(vals, valsOffset+valsStride, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[half:], baseCase)
+		fs.radix2Butterflies(rootsOfUnity, rootsOfUnityStride, out)
+		return
+	}
+	q := n >> 2
+	for j := uint64(0); j < 4; j++ {
+		fs._fftRadix4(vals, valsOffset+j*valsStride, valsStride<<2, rootsOfUnity, rootsOfUnityStride<<2, out[j*q:(j+1)*q], baseCase)
+	}
+
+	b := fs.backend()
+	// i = w^(n/4), a square root of -1
+	imag := &rootsOfUnity[q*rootsOfUnityStride]
+	var a0, a1, a2, a3, t0, t1, t2, t3 ff.Fr
+	for k := uint64(0); k < q; k++ {
+		ff.CopyFr(&a0, &out[k])
+		if k == 0 {
+			ff.CopyFr(&a1, &out[q])
+			ff.CopyFr(&a2, &out[2*q])
+			ff.CopyFr(&a3, &out[3*q])
+		} else {
+			b.MulModFr(&a1, &out[k+q], &rootsOfUnity[k*rootsOfUnityStride])
+			b.MulModFr(&a2, &out[k+2*q], &rootsOfUnity[2*k*rootsOfUnityStride])
+			b.MulModFr(&a3, &out[k+3*q], &rootsOfUnity[3*k*rootsOfUnityStride])
+		}
+		b.AddModFr(&t0, &a0, &a2) // a0 + a2
+		b.SubModFr(&t1, &a0, &a2) // a0 - a2
+		b.AddModFr(&t2, &a1, &a3) // a1 + a3
+		b.SubModFr(&t3, &a1, &a3) // a1 - a3
+		b.MulModFr(&a3, &t3, imag)
+		b.AddModFr(&out[k], &t0, &t2)
+		b.SubModFr(&out[k+2*q], &t0, &t2)
+		b.AddModFr(&out[k+q], &t1, &a3)
+		b.SubModFr(&out[k+3*q], &t1, &a3)
+	}
+}
+
+func (fs *FFTSettings) _fftSplitRadix(vals []ff.Fr, valsOffset uint64, valsStride uint64, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, out []ff.Fr, baseCase uint64) {
+	n := uint64(len(out))
+	if n <= baseCase || n == 1 {
+		fs.simpleFT(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out)
+		return
+	}
+	if n == 2 {
+		fs._fft(vals, valsOffset, valsStride, rootsOfUnity, rootsOfUnityStride, out, baseCase)
+		return
+	}
+	half := n >> 1
+	q := n >> 2
+	// E: the even values, into the first half of out
+	fs._fftSplitRadix(vals, valsOffset, valsStride<<1, rootsOfUnity, rootsOfUnityStride<<1, out[:half], baseCase)
+	// O1 and O3: the values at 1 and 3 modulo 4, into the last two quarters of out
+	fs._fftSplitRadix(vals, valsOffset+valsStride, valsStride<<2, rootsOfUnity, rootsOfUnityStride<<2, out[half:half+q], baseCase)
+	fs._fftSplitRadix(vals, valsOffset+3*valsStride, valsStride<<2, rootsOfUnity, rootsOfUnityStride<<2, out[half+q:], baseCase)
+
+	b := fs.backend()
+	// i = w^(n/4), a square root of -1
+	imag := &rootsOfUnity[q*rootsOfUnityStride]
+	var e0, e1, u, z, sum, diff ff.Fr
+	for k := uint64(0); k < q; k++ {
+		ff.CopyFr(&e0, &out[k])
+		ff.CopyFr(&e1, &out[k+q])
+		if k == 0 {
+			ff.CopyFr(&u, &out[half])
+			ff.CopyFr(&z, &out[half+q])
+		} else {
+			b.MulModFr(&u, &out[half+k], &rootsOfUnity[k*rootsOfUnityStride])
+			b.MulModFr(&z, &out[half+q+k], &rootsOfUnity[3*k*rootsOfUnityStride])
+		}
+		b.AddModFr(&sum, &u, &z)
+		b.SubModFr(&diff, &u, &z)
+		b.MulModFr(&u, &diff, imag) // i * (u - z)
+		b.AddModFr(&out[k], &e0, &sum)
+		b.SubModFr(&out[k+half], &e0, &sum)
+		b.AddModFr(&out[k+q], &e1, &u)
+		b.SubModFr(&out[k+half+q], &e1, &u)
+	}
+}
+
+// TuneKernels measures the kernels with different base case sizes, for each power of two size up to 2^maxScale
+// (capped at MaxWidth and 2^12), and returns a copy of the settings with KernelAuto, to use the fastest choice
+// per size. The settings themselves are not modified, so other goroutines can keep using them meanwhile.
+// The outputs of all kernels are the same, only the speed differs.
+func (fs *FFTSettings) TuneKernels(maxScale uint8) *FFTSettings {
+	if maxScale > maxTuneScale {
+		maxScale = maxTuneScale
+	}
+	if width := uint8(bits.Len64(fs.MaxWidth) - 1); maxScale > width {
+		maxScale = width
+	}
+	candidates := []kernelChoice{}
+	for _, kernel := range []FFTKernel{KernelRadix2, KernelRadix4, KernelSplitRadix} {
+		for _, baseCase := range []uint64{1, 2, 4, 8} {
+			candidates = append(candidates, kernelChoice{kernel: kernel, baseCase: baseCase})
+		}
+	}
+	candidates = append(candidates, kernelChoice{kernel: KernelIterative})
+	rootz := fs.ExpandedRootsOfUnity[:fs.MaxWidth]
+	tuned := make([]kernelChoice, maxScale+1, maxScale+1)
+	for scale := uint8(0); scale <= maxScale; scale++ {
+		n := uint64(1) << scale
+		vals := make([]ff.Fr, n, n)
+		for i := range vals {
+			ff.AsFr(&vals[i], uint64(i)*0x9e3779b97f4a7c15+1)
+		}
+		out := make([]ff.Fr, n, n)
+		// repeat small sizes, so the timings are not just noise
+		reps := 1 + (1<<maxTuneScale)/int(n)/4
+		best := time.Duration(-1)
+		for _, c := range candidates {
+			start := time.Now()
+			for r := 0; r < reps; r++ {
+				fs.runKernel(c, vals, 0, 1, rootz, fs.MaxWidth/n, out)
+			}
+			if elapsed := time.Since(start); best < 0 || elapsed < best {
+				best = elapsed
+				tuned[scale] = c
+			}
+		}
+	}
+	out := *fs
+	out.Kernel = KernelAuto
+	out.tunedKernels = tuned
+	return &out
+}
@@ -0,0 +1,136 @@
+package fft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+func TestFFTKernels(t *testing.T) {
+	fs := NewFFTSettings(9)
+	for scale := uint8(0); scale <= 9; scale++ {
+		n := uint64(1) << scale
+		data := make([]ff.Fr, n, n)
+		for i := range data {
+			ff.CopyFr(&data[i], ff.RandomFr())
+		}
+		for _, inv := range []bool{false, true} {
+			fs.Kernel = KernelDefault
+			fs.MaxGoroutines = 0
+			expected, err := fs.FFT(data, inv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the kernels also run with the top levels split up over goroutines
+			for _, goroutines := range []int{0, 4} {
+				for _, kernel := range []FFTKernel{KernelRadix2, KernelRadix4, KernelSplitRadix, KernelIterative} {
+					for _, baseCase := range []uint64{1, 2, 4, 16} {
+						fs.Kernel = kernel
+						fs.BaseCaseSize = baseCase
+						fs.MaxGoroutines = goroutines
+						got, err := fs.FFT(data, inv)
+						if err != nil {
+							t.Fatal(err)
+						}
+						for i := range got {
+							if !ff.EqualFr(&got[i], &expected[i]) {
+								t.Fatalf("%s, base case %d, goroutines %d, scale %d, inv %v: value %d differs: got %s, expected %s",
+									kernel, baseCase, goroutines, scale, inv, i, ff.FrStr(&got[i]), ff.FrStr(&expected[i]))
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// mulCountingBackend counts the field multiplications of the backend it wraps.
+type mulCountingBackend struct {
+	ff.Backend
+	muls int
+}
+
+func (b *mulCountingBackend) MulModFr(dst *ff.Fr, x, y *ff.Fr) {
+	b.muls++
+	b.Backend.MulModFr(dst, x, y)
+}
+
+func TestFFTKernelMultiplications(t *testing.T) {
+	counter := &mulCountingBackend{Backend: ff.DefaultBackend()}
+	fs := NewFFTSettingsWithBackend(10, counter)
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+	for i := range data {
+		ff.AsFr(&data[i], uint64(i))
+	}
+	out := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+	rootz := fs.ExpandedRootsOfUnity[:fs.MaxWidth]
+	// (n/2)*log2(n) - n + 1, all twiddle factors except 1 need a multiplication
+	expected := int(fs.MaxWidth/2*10 - fs.MaxWidth + 1)
+	for _, kernel := range []FFTKernel{KernelRadix2, KernelRadix4, KernelSplitRadix, KernelIterative} {
+		counter.muls = 0
+		fs.runKernel(kernelChoice{kernel: kernel, baseCase: 1}, data, 0, 1, rootz, 1, out)
+		if counter.muls != expected {
+			t.Errorf("%s: got %d multiplications, expected %d", kernel, counter.muls, expected)
+		}
+	}
+}
+
+func TestTuneKernels(t *testing.T) {
+	fs := NewFFTSettings(9)
+	data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+	for i := range data {
+		ff.CopyFr(&data[i], ff.RandomFr())
+	}
+	expected, err := fs.FFT(data, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tuned := fs.TuneKernels(10)
+	if fs.Kernel != KernelDefault || fs.tunedKernels != nil {
+		t.Fatalf("expected the original settings to be unchanged, got kernel %s", fs.Kernel)
+	}
+	if tuned.Kernel != KernelAuto {
+		t.Fatalf("expected auto kernel, got %s", tuned.Kernel)
+	}
+	if len(tuned.tunedKernels) != 10 {
+		t.Fatalf("expected a choice for scales 0 to 9, got %d", len(tuned.tunedKernels))
+	}
+	for scale, c := range tuned.tunedKernels {
+		t.Logf("scale %d: %s, base case %d", scale, c.kernel, c.baseCase)
+	}
+	for _, goroutines := range []int{0, 4} {
+		tuned.MaxGoroutines = goroutines
+		got, err := tuned.FFT(data, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range got {
+			if !ff.EqualFr(&got[i], &expected[i]) {
+				t.Fatalf("goroutines %d: value %d differs: got %s, expected %s", goroutines, i, ff.FrStr(&got[i]), ff.FrStr(&expected[i]))
+			}
+		}
+	}
+}
+
+func BenchmarkFFTKernels(b *testing.B) {
+	for _, kernel := range []FFTKernel{KernelDefault, KernelRadix2, KernelRadix4, KernelSplitRadix, KernelIterative} {
+		for scale := uint8(8); scale <= 14; scale += 2 {
+			fs := NewFFTSettings(scale)
+			fs.Kernel = kernel
+			fs.BaseCaseSize = 1
+			data := make([]ff.Fr, fs.MaxWidth, fs.MaxWidth)
+			for i := range data {
+				ff.CopyFr(&data[i], ff.RandomFr())
+			}
+			b.Run(fmt.Sprintf("%s/scale_%d", kernel, scale), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := fs.FFT(data, false); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
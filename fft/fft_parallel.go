@@ -0,0 +1,98 @@
+package fft
+
+import (
+	"sync"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+// Below these sizes, parallel transforms do the remaining work on a single goroutine.
+const (
+	minParallelFFTSize   = 256
+	minParallelFFTG1Size = 8
+	minParallelFFTG2Size = 4
+)
+
+// parallelChunks splits [0, n) into at most chunks ranges, and runs fn on them concurrently, see ff.ParallelRange.
+func parallelChunks(n uint64, chunks int, fn func(start, end uint64)) {
+	if chunks < 1 {
+		// ff.ParallelRange takes this as runtime.NumCPU()
+		chunks = 1
+	}
+	ff.ParallelRange(int(n), chunks, 1, func(start, end int) {
+		fn(uint64(start), uint64(end))
+	})
+}
+
+// parallelHalves runs both functions, the first one on a new goroutine, and waits for them to complete.
+func parallelHalves(left func(), right func()) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		left()
+	}()
+	right()
+	wg.Wait()
+}
+
+// difFFTParallel is difFFT, with the two halves of the remaining depth levels transformed concurrently.
+// The butterflies of each level are split up over goroutines, halved for each of the two halves.
+func (fs *FFTSettings) difFFTParallel(vals []ff.Fr, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, depth uint8, goroutines int) {
+	n := uint64(len(vals))
+	if depth == 0 || n < minParallelFFTSize {
+		fs.difFFT(vals, rootsOfUnity, rootsOfUnityStride)
+		return
+	}
+	b := fs.backend()
+	half := n >> 1
+	parallelChunks(half, goroutines, func(start, end uint64) {
+		var x, y, diff ff.Fr
+		for j := start; j < end; j++ {
+			ff.CopyFr(&x, &vals[j])
+			ff.CopyFr(&y, &vals[j+half])
+			b.AddModFr(&vals[j], &x, &y)
+			b.SubModFr(&diff, &x, &y)
+			if j == 0 {
+				ff.CopyFr(&vals[j+half], &diff)
+			} else {
+				b.MulModFr(&vals[j+half], &diff, &rootsOfUnity[j*rootsOfUnityStride])
+			}
+		}
+	})
+	parallelHalves(func() {
+		fs.difFFTParallel(vals[:half], rootsOfUnity, rootsOfUnityStride<<1, depth-1, goroutines>>1)
+	}, func() {
+		fs.difFFTParallel(vals[half:], rootsOfUnity, rootsOfUnityStride<<1, depth-1, goroutines>>1)
+	})
+}
+
+// ditFFTParallel is ditFFT, with the two halves of the remaining depth levels transformed concurrently.
+// The butterflies of each level are split up over goroutines, halved for each of the two halves.
+func (fs *FFTSettings) ditFFTParallel(vals []ff.Fr, rootsOfUnity []ff.Fr, rootsOfUnityStride uint64, depth uint8, goroutines int) {
+	n := uint64(len(vals))
+	if depth == 0 || n < minParallelFFTSize {
+		fs.ditFFT(vals, rootsOfUnity, rootsOfUnityStride)
+		return
+	}
+	b := fs.backend()
+	half := n >> 1
+	parallelHalves(func() {
+		fs.ditFFTParallel(vals[:half], rootsOfUnity, rootsOfUnityStride<<1, depth-1, goroutines>>1)
+	}, func() {
+		fs.ditFFTParallel(vals[half:], rootsOfUnity, rootsOfUnityStride<<1, depth-1, goroutines>>1)
+	})
+	parallelChunks(half, goroutines, func(start, end uint64) {
+		var x, yTimesRoot ff.Fr
+		for j := start; j < end; j++ {
+			ff.CopyFr(&x, &vals[j])
+			if j == 0 {
+				ff.CopyFr(&yTimesRoot, &vals[j+half])
+			} else {
+				b.MulModFr(&yTimesRoot, &vals[j+half], &rootsOfUnity[j*rootsOfUnityStride])
+			}
+			b.AddModFr(&vals[j], &x, &yTimesRoot)
+			b.SubModFr(&vals[j+half], &x, &yTimesRoot)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package fft
+
+import (
+	"testing"
+
+	"github.com/sshravan/go-poly/ff"
+)
+
+func TestParallelDepth(t *testing.T) {
+	fs := NewFFTSettings(4)
+	for _, c := range []struct {
+		maxGoroutines int
+		cutoffDepth   uint8
+		expected      uint8
+	}{{0, 0, 0}, {1, 5, 0}, {2, 0, 1}, {7, 0, 2}, {8, 0, 3}, {64, 2, 2}, {4, 3, 2}} {
+		fs.MaxGoroutines = c.maxGoroutines
+		fs.ParallelCutoffDepth = c.cutoffDepth
+		if got := fs.parallelDepth(); got != c.expected {
+			t.Errorf("max goroutines %d, cutoff depth %d: got %d, expected %d", c.maxGoroutines, c.cutoffDepth, got, c.expected)
+		}
+	}
+}
+
+func TestParallelFFT(t *testing.T) {
+	serial := NewFFTSettings(12)
+	// the cutoff depth only limits the recursion, the butterflies are still split up over all goroutines
+	for _, c := range []struct {
+		maxGoroutines int
+		cutoffDepth   uint8
+	}{{8, 0}, {8, 1}, {3, 0}, {64, 2}} {
+		parallel := NewFFTSettings(12)
+		parallel.MaxGoroutines = c.maxGoroutines
+		parallel.ParallelCutoffDepth = c.cutoffDepth
+		for _, scale := range []uint8{3, 9, 12} {
+			n := uint64(1) << scale
+			data := make([]ff.Fr, n, n)
+			for i := range data {
+				ff.CopyFr(&data[i], ff.RandomFr())
+			}
+			for _, inv := range []bool{false, true} {
+				expected, err := serial.FFT(data, inv)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := parallel.FFT(data, inv)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := range got {
+					if !ff.EqualFr(&got[i], &expected[i]) {
+						t.Fatalf("goroutines %d, cutoff %d, scale %d, inv %v: value %d differs: got %s, expected %s",
+							c.maxGoroutines, c.cutoffDepth, scale, inv, i, ff.FrStr(&got[i]), ff.FrStr(&expected[i]))
+					}
+				}
+				// and the DIT kernel takes the bit-reversed evaluations back
+				ReverseBitOrderFr(got)
+				if err := parallel.FFTDIT(got, !inv); err != nil {
+					t.Fatal(err)
+				}
+				for i := range got {
+					if !ff.EqualFr(&got[i], &data[i]) {
+						t.Fatalf("goroutines %d, cutoff %d, scale %d, inv %v: roundtrip value %d differs",
+							c.maxGoroutines, c.cutoffDepth, scale, inv, i)
+					}
+				}
+			}
+		}
+	}
+}
@@ -25,57 +25,58 @@ func (fs *FFTSettings) mulPolysWithFFT(a []ff.Fr, b []ff.Fr, rootsOfUnityStride
 	rootz := fs.ExpandedRootsOfUnity[:fs.MaxWidth]
 	// Get FFT of a and b
 	x1 := make([]ff.Fr, len(aVals), len(aVals))
-	fs._fft(aVals, 0, 1, rootz, rootsOfUnityStride, x1)
+	fs.kernelFFT(aVals, rootz, rootsOfUnityStride, x1)
 
 	x2 := make([]ff.Fr, len(bVals), len(bVals))
-	fs._fft(bVals, 0, 1, rootz, rootsOfUnityStride, x2)
+	fs.kernelFFT(bVals, rootz, rootsOfUnityStride, x2)
 
 	// multiply the two. Hack: store results in x1
 	var tmp ff.Fr
 	for i := 0; i < len(x1); i++ {
 		ff.CopyFr(&tmp, &x1[i])
-		ff.MulModFr(&x1[i], &tmp, &x2[i])
+		fs.backend().MulModFr(&x1[i], &tmp, &x2[i])
 	}
 	revRootz := fs.ReverseRootsOfUnity[:fs.MaxWidth]
 
 	out := make([]ff.Fr, len(x1), len(x1))
 	// compute the FFT of the multiplied values.
-	fs._fft(x1, 0, 1, revRootz, rootsOfUnityStride, out)
+	fs.kernelFFT(x1, revRootz, rootsOfUnityStride, out)
 	return out
 }
 
+// The shift factor of ShiftPoly and UnshiftPoly.
+const polyShiftFactor = 5
+
+// scaleByPowers sets dst[i] = vals[i] * powers[i] on the backend of the settings, dst may alias vals.
+func (fs *FFTSettings) scaleByPowers(dst []ff.Fr, vals []ff.Fr, powers []ff.Fr) {
+	b := fs.backend()
+	var tmp ff.Fr
+	for i := range vals {
+		b.MulModFr(&tmp, &vals[i], &powers[i])
+		ff.CopyFr(&dst[i], &tmp)
+	}
+}
+
 // unshift poly, in-place. Multiplies each coeff with 1/shift_factor**i
 func (fs *FFTSettings) ShiftPoly(poly []ff.Fr) {
 	var shiftFactor ff.Fr
-	ff.AsFr(&shiftFactor, 5) // primitive root of unity
-	var factorPower ff.Fr
-	ff.CopyFr(&factorPower, &ff.ONE)
-	var invFactor ff.Fr
-	ff.InvModFr(&invFactor, &shiftFactor)
-	var tmp ff.Fr
-	for i := 0; i < len(poly); i++ {
-		ff.CopyFr(&tmp, &poly[i])
-		ff.MulModFr(&poly[i], &tmp, &factorPower)
-		// TODO: pre-compute all these shift scalars
-		ff.CopyFr(&tmp, &factorPower)
-		ff.MulModFr(&factorPower, &tmp, &invFactor)
+	ff.AsFr(&shiftFactor, polyShiftFactor) // primitive root of unity
+	p, err := fs.shiftPowers(&shiftFactor, uint64(len(poly)))
+	if err != nil {
+		panic(err)
 	}
+	fs.scaleByPowers(poly, poly, p.invPowers)
 }
 
 // unshift poly, in-place. Multiplies each coeff with shift_factor**i
 func (fs *FFTSettings) UnshiftPoly(poly []ff.Fr) {
 	var shiftFactor ff.Fr
-	ff.AsFr(&shiftFactor, 5) // primitive root of unity
-	var factorPower ff.Fr
-	ff.CopyFr(&factorPower, &ff.ONE)
-	var tmp ff.Fr
-	for i := 0; i < len(poly); i++ {
-		ff.CopyFr(&tmp, &poly[i])
-		ff.MulModFr(&poly[i], &tmp, &factorPower)
-		// TODO: pre-compute all these shift scalars
-		ff.CopyFr(&tmp, &factorPower)
-		ff.MulModFr(&factorPower, &tmp, &shiftFactor)
+	ff.AsFr(&shiftFactor, polyShiftFactor) // primitive root of unity
+	p, err := fs.shiftPowers(&shiftFactor, uint64(len(poly)))
+	if err != nil {
+		panic(err)
 	}
+	fs.scaleByPowers(poly, poly, p.powers)
 }
 
 func (fs *FFTSettings) makeZeroPolyMulLeaf(dst []ff.Fr, indices []uint64, domainStride uint64) {
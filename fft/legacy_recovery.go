@@ -8,34 +8,11 @@ import (
 	"github.com/sshravan/go-poly/ff"
 )
 
-// Calculates modular inverses [1/values[0], 1/values[1] ...]
-func multiInv(values []ff.Fr) []ff.Fr {
-	partials := make([]ff.Fr, len(values)+1, len(values)+1)
-	partials[0] = values[0]
-	for i := 0; i < len(values); i++ {
-		ff.MulModFr(&partials[i+1], &partials[i], &values[i])
-	}
-	var inv ff.Fr
-	var tmp ff.Fr
-	ff.InvModFr(&inv, &partials[len(partials)-1])
-	outputs := make([]ff.Fr, len(values), len(values))
-	for i := len(values); i > 0; i-- {
-		ff.MulModFr(&outputs[i-1], &partials[i-1], &inv)
-		ff.CopyFr(&tmp, &inv)
-		ff.MulModFr(&inv, &tmp, &values[i-1])
-	}
-	return outputs
-}
-
 // Generates q(x) = poly(k * x)
 func pOfKX(poly []ff.Fr, k *ff.Fr) []ff.Fr {
 	out := make([]ff.Fr, len(poly), len(poly))
-	powerOfK := ff.ONE
-	var tmp ff.Fr
-	for i := range poly {
-		ff.MulModFr(&out[i], &poly[i], &powerOfK)
-		ff.CopyFr(&tmp, &powerOfK)
-		ff.MulModFr(&powerOfK, &tmp, k)
+	if err := ff.HadamardVecFr(out, poly, ff.PowersOfFr(k, len(poly))); err != nil {
+		panic(err)
 	}
 	return out
 }
@@ -162,14 +139,12 @@ func (fs *FFTSettings) ErasureCodeRecover(vals []*ff.Fr) ([]ff.Fr, error) {
 	// Check only with primitive roots of unity
 	attempts := 0
 	var kFr ff.Fr
-	var tmp ff.Fr
 	for k := uint64(2); attempts < maxRecoverAttempts; k++ {
 		ff.AsFr(&kFr, k)
-		// // TODO: implement this, translation of 'if pow(k, (modulus - 1) // 2, modulus) == 1:'
-		//someOp(&tmp, &kFr)
-		//if EqualOne(&tmp) {
-		//	continue
-		//}
+		// Only use quadratic non-residues, 'if pow(k, (modulus - 1) // 2, modulus) == 1: continue'
+		if ff.LegendreFr(&kFr) == 1 {
+			continue
+		}
 		var invk ff.Fr
 		ff.InvModFr(&invk, &kFr)
 		// Convert p_times_z(x) and z(x) into new polynomials
@@ -191,11 +166,16 @@ func (fs *FFTSettings) ErasureCodeRecover(vals []*ff.Fr) ([]ff.Fr, error) {
 		//debug.DebugFrs("z_of_kx_vals", zOfKXVals)
 
 		// Compute q1(x) / q2(x) = p(k*x)
-		invZOfKXVals := multiInv(zOfKXVals)
+		invZOfKXVals := make([]ff.Fr, len(zOfKXVals), len(zOfKXVals))
+		if err := ff.BatchInvModFr(invZOfKXVals, zOfKXVals, false); err != nil {
+			// q2(x) is 0 at one of the evaluation points, try another k
+			attempts += 1
+			continue
+		}
 		//debug.DebugFrs("inv_z_of_kv_vals", invZOfKXVals)
 		pOfKxVals := make([]ff.Fr, len(pTimesZOfKXVals), len(pTimesZOfKXVals))
-		for i := 0; i < len(pOfKxVals); i++ {
-			ff.MulModFr(&pOfKxVals[i], &pTimesZOfKXVals[i], &invZOfKXVals[i])
+		if err := ff.HadamardVecFr(pOfKxVals, pTimesZOfKXVals, invZOfKXVals); err != nil {
+			return nil, err
 		}
 		//debug.DebugFrs("p_of_kx_vals", pOfKxVals)
 		pOfKx, err := fs.FFT(pOfKxVals, true)
@@ -206,17 +186,8 @@ func (fs *FFTSettings) ErasureCodeRecover(vals []*ff.Fr) ([]ff.Fr, error) {
 
 		// Given q3(x) = p(k*x), recover p(x)
 		pOfX := make([]ff.Fr, len(pOfKx), len(pOfKx))
-		if len(pOfKx) >= 1 {
-			pOfX[0] = pOfKx[0]
-		}
-		if len(pOfKx) >= 2 {
-			ff.MulModFr(&pOfX[1], &pOfKx[1], &invk)
-			invKPowI := invk
-			for i := 2; i < len(pOfKx); i++ {
-				ff.CopyFr(&tmp, &invKPowI)
-				ff.MulModFr(&invKPowI, &tmp, &invk)
-				ff.MulModFr(&pOfX[i], &pOfKx[i], &invKPowI)
-			}
+		if err := ff.HadamardVecFr(pOfX, pOfKx, ff.PowersOfFr(&invk, len(pOfKx))); err != nil {
+			return nil, err
 		}
 		output, err := fs.FFT(pOfX, false)
 		if err != nil {
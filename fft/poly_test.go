@@ -2,12 +2,22 @@ package fft
 
 import (
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/sshravan/go-poly/debug"
 	"github.com/sshravan/go-poly/ff"
 )
 
+// seededFr returns the next random value from a seeded reader, so failing cases can be replayed from the seed.
+func seededFr(t *testing.T, rng io.Reader) ff.Fr {
+	v, err := ff.RandomFrFrom(rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *v
+}
+
 func CheckEqualVec(a []ff.Fr, b []ff.Fr) bool {
 	n := len(a)
 	if n == len(b) && n > 0 {
@@ -434,9 +444,10 @@ func TestPolySubProdTree(t *testing.T) {
 		testname := fmt.Sprintf("scale-%d", l)
 		t.Run(testname, func(t *testing.T) {
 			n := 1 << l
+			rng := ff.NewSeededReader(int64(l))
 			aFr := make([]ff.Fr, n, n)
 			for i := 0; i < n; i++ {
-				aFr[i] = *ff.RandomFr()
+				aFr[i] = seededFr(t, rng)
 			}
 			var temp ff.Fr
 			result := []ff.Fr{ff.ONE}
@@ -578,6 +589,7 @@ func TestPolyMultiPointEval(t *testing.T) {
 		testname := fmt.Sprintf("MultiPointEval/scale-%d", l)
 		t.Run(testname, func(t *testing.T) {
 			n := 1 << l
+			rng := ff.NewSeededReader(int64(l))
 
 			aFr := make([]ff.Fr, n-1, n-1) // Not n!
 			evalPointsFr := make([]ff.Fr, n, n)
@@ -585,9 +597,9 @@ func TestPolyMultiPointEval(t *testing.T) {
 
 			for i := 0; i < n; i++ {
 				if i < n-1 {
-					aFr[i] = *ff.RandomFr()
+					aFr[i] = seededFr(t, rng)
 				}
-				evalPointsFr[i] = *ff.RandomFr()
+				evalPointsFr[i] = seededFr(t, rng)
 			}
 
 			polynomialFr := PolyTree(aFr)
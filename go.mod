@@ -1,9 +1,19 @@
 module github.com/sshravan/go-poly
 
-go 1.16
+go 1.18
 
 require (
 	github.com/alinush/go-mcl v0.0.0-20210224202455-eb6000c9b115
+	github.com/consensys/gnark-crypto v0.12.1
+	github.com/herumi/bls-eth-go-binary v1.31.0
+	github.com/kilic/bls12-381 v0.1.0
+)
+
+require (
+	github.com/bits-and-blooms/bitset v1.7.0 // indirect
+	github.com/consensys/bavard v0.1.13 // indirect
+	github.com/mmcloughlin/addchain v0.4.0 // indirect
 	github.com/sirupsen/logrus v1.9.3 // indirect
 	golang.org/x/sys v0.28.0 // indirect
+	rsc.io/tmplfunc v0.0.3 // indirect
 )